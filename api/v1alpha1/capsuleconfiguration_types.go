@@ -8,8 +8,7 @@ import (
 )
 
 // CapsuleConfigurationSpec defines the Capsule configuration
-// nolint:maligned
-type CapsuleConfigurationSpec struct {
+type CapsuleConfigurationSpec struct { //nolint:maligned
 	// Names of the groups for Capsule users.
 	// +kubebuilder:default={capsule.clastix.io}
 	UserGroups []string `json:"userGroups,omitempty"`
@@ -4,6 +4,7 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -21,9 +22,30 @@ const (
 
 	podPriorityAllowedAnnotation      = "priorityclass.capsule.clastix.io/allowed"
 	podPriorityAllowedRegexAnnotation = "priorityclass.capsule.clastix.io/allowed-regex"
+	podPriorityDefaultAnnotation      = "priorityclass.capsule.clastix.io/default"
+	podPriorityRequiredAnnotation     = "priorityclass.capsule.clastix.io/required"
+
+	storageClassDefaultAnnotation  = "storageclass.capsule.clastix.io/default"
+	storageClassRequiredAnnotation = "storageclass.capsule.clastix.io/required"
+	ingressClassDefaultAnnotation  = "ingressclass.capsule.clastix.io/default"
+	ingressClassRequiredAnnotation = "ingressclass.capsule.clastix.io/required"
+
+	resourceQuotaNamespaceScopedAnnotation = "quota.capsule.clastix.io/namespace-scoped-items"
+
+	deletionPolicyAnnotation    = "capsule.clastix.io/deletion-policy"
+	namespaceAdoptionAnnotation = "capsule.clastix.io/namespace-adoption"
+	containerImagesAnnotation   = "capsule.clastix.io/container-images"
+	pvcAnnotation               = "capsule.clastix.io/persistent-volume-claims"
 
 	enableNodePortsAnnotation    = "capsule.clastix.io/enable-node-ports"
 	enableExternalNameAnnotation = "capsule.clastix.io/enable-external-name"
+	enableLoadBalancerAnnotation = "capsule.clastix.io/enable-load-balancer"
+	loadBalancersAnnotation      = "capsule.clastix.io/load-balancers"
+	nodePortsAnnotation          = "capsule.clastix.io/node-ports"
+
+	tenantNameMaxLengthAnnotation = "capsule.clastix.io/tenant-name-max-length"
+	reservedTenantNamesAnnotation = "capsule.clastix.io/reserved-tenant-names"
+	nodePortRangeAnnotation       = "capsule.clastix.io/node-port-range"
 
 	ownerGroupsAnnotation         = "owners.capsule.clastix.io/group"
 	ownerUsersAnnotation          = "owners.capsule.clastix.io/user"
@@ -154,15 +176,39 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 	if t.Spec.StorageClasses != nil {
-		dst.Spec.StorageClasses = &capsulev1beta1.AllowedListSpec{
-			Exact: t.Spec.StorageClasses.Exact,
-			Regex: t.Spec.StorageClasses.Regex,
+		dst.Spec.StorageClasses = &capsulev1beta1.DefaultAllowedListSpec{
+			AllowedListSpec: capsulev1beta1.AllowedListSpec{
+				Exact: t.Spec.StorageClasses.Exact,
+				Regex: t.Spec.StorageClasses.Regex,
+			},
+		}
+		if storageClassDefault, ok := annotations[storageClassDefaultAnnotation]; ok {
+			dst.Spec.StorageClasses.Default = storageClassDefault
+		}
+		if storageClassRequired, ok := annotations[storageClassRequiredAnnotation]; ok {
+			val, err := strconv.ParseBool(storageClassRequired)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", storageClassRequiredAnnotation, t.GetName()))
+			}
+			dst.Spec.StorageClasses.Required = val
 		}
 	}
 	if t.Spec.IngressClasses != nil {
-		dst.Spec.IngressClasses = &capsulev1beta1.AllowedListSpec{
-			Exact: t.Spec.IngressClasses.Exact,
-			Regex: t.Spec.IngressClasses.Regex,
+		dst.Spec.IngressClasses = &capsulev1beta1.DefaultAllowedListSpec{
+			AllowedListSpec: capsulev1beta1.AllowedListSpec{
+				Exact: t.Spec.IngressClasses.Exact,
+				Regex: t.Spec.IngressClasses.Regex,
+			},
+		}
+		if ingressClassDefault, ok := annotations[ingressClassDefaultAnnotation]; ok {
+			dst.Spec.IngressClasses.Default = ingressClassDefault
+		}
+		if ingressClassRequired, ok := annotations[ingressClassRequiredAnnotation]; ok {
+			val, err := strconv.ParseBool(ingressClassRequired)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", ingressClassRequiredAnnotation, t.GetName()))
+			}
+			dst.Spec.IngressClasses.Required = val
 		}
 	}
 	if t.Spec.IngressHostnames != nil {
@@ -188,8 +234,26 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 	if len(t.Spec.ResourceQuota) > 0 {
+		namespaceScoped := make(map[string]struct{})
+		if val, ok := annotations[resourceQuotaNamespaceScopedAnnotation]; ok {
+			for _, index := range strings.Split(val, ",") {
+				namespaceScoped[index] = struct{}{}
+			}
+		}
+
 		dst.Spec.ResourceQuota = &capsulev1beta1.ResourceQuotaSpec{
-			Items: t.Spec.ResourceQuota,
+			Items: make([]capsulev1beta1.ResourceQuotaItem, 0, len(t.Spec.ResourceQuota)),
+		}
+		for i, item := range t.Spec.ResourceQuota {
+			scope := capsulev1beta1.ResourceQuotaScopeTenant
+			if _, ok := namespaceScoped[strconv.Itoa(i)]; ok {
+				scope = capsulev1beta1.ResourceQuotaScopeNamespace
+			}
+
+			dst.Spec.ResourceQuota.Items = append(dst.Spec.ResourceQuota.Items, capsulev1beta1.ResourceQuotaItem{
+				Scope:             scope,
+				ResourceQuotaSpec: item,
+			})
 		}
 	}
 	if len(t.Spec.AdditionalRoleBindings) > 0 {
@@ -220,7 +284,7 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
-	priorityClasses := capsulev1beta1.AllowedListSpec{}
+	priorityClasses := capsulev1beta1.DefaultAllowedListSpec{}
 
 	priorityClassAllowed, ok := annotations[podPriorityAllowedAnnotation]
 	if ok {
@@ -231,6 +295,19 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 		priorityClasses.Regex = priorityClassesRegexp
 	}
 
+	priorityClassDefault, ok := annotations[podPriorityDefaultAnnotation]
+	if ok {
+		priorityClasses.Default = priorityClassDefault
+	}
+	priorityClassRequired, ok := annotations[podPriorityRequiredAnnotation]
+	if ok {
+		val, err := strconv.ParseBool(priorityClassRequired)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", podPriorityRequiredAnnotation, t.GetName()))
+		}
+		priorityClasses.Required = val
+	}
+
 	if !reflect.ValueOf(priorityClasses).IsZero() {
 		dst.Spec.PriorityClasses = &priorityClasses
 	}
@@ -265,6 +342,66 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.ServiceOptions.AllowedServices.ExternalName = pointer.BoolPtr(val)
 	}
 
+	enableLoadBalancer, ok := annotations[enableLoadBalancerAnnotation]
+	if ok {
+		val, err := strconv.ParseBool(enableLoadBalancer)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", enableLoadBalancerAnnotation, t.GetName()))
+		}
+		if dst.Spec.ServiceOptions == nil {
+			dst.Spec.ServiceOptions = &capsulev1beta1.ServiceOptions{}
+		}
+		if dst.Spec.ServiceOptions.AllowedServices == nil {
+			dst.Spec.ServiceOptions.AllowedServices = &capsulev1beta1.AllowedServices{}
+		}
+		dst.Spec.ServiceOptions.AllowedServices.LoadBalancer = pointer.BoolPtr(val)
+	}
+
+	if loadBalancers, ok := annotations[loadBalancersAnnotation]; ok {
+		if dst.Spec.ServiceOptions == nil {
+			dst.Spec.ServiceOptions = &capsulev1beta1.ServiceOptions{}
+		}
+		dst.Spec.ServiceOptions.LoadBalancers = &capsulev1beta1.LoadBalancerSpec{}
+		if err := json.Unmarshal([]byte(loadBalancers), dst.Spec.ServiceOptions.LoadBalancers); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", loadBalancersAnnotation, t.GetName()))
+		}
+	}
+
+	if nodePorts, ok := annotations[nodePortsAnnotation]; ok {
+		if dst.Spec.ServiceOptions == nil {
+			dst.Spec.ServiceOptions = &capsulev1beta1.ServiceOptions{}
+		}
+		dst.Spec.ServiceOptions.NodePorts = &capsulev1beta1.NodePortsSpec{}
+		if err := json.Unmarshal([]byte(nodePorts), dst.Spec.ServiceOptions.NodePorts); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", nodePortsAnnotation, t.GetName()))
+		}
+	}
+
+	if deletionPolicy, ok := annotations[deletionPolicyAnnotation]; ok {
+		dst.Spec.DeletionPolicy = capsulev1beta1.DeletionPolicy(deletionPolicy)
+	}
+
+	if adoption, ok := annotations[namespaceAdoptionAnnotation]; ok {
+		dst.Spec.NamespaceAdoption = &capsulev1beta1.NamespaceAdoptionSpec{}
+		if err := json.Unmarshal([]byte(adoption), dst.Spec.NamespaceAdoption); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", namespaceAdoptionAnnotation, t.GetName()))
+		}
+	}
+
+	if images, ok := annotations[containerImagesAnnotation]; ok {
+		dst.Spec.ContainerImages = &capsulev1beta1.ContainerImagesSpec{}
+		if err := json.Unmarshal([]byte(images), dst.Spec.ContainerImages); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", containerImagesAnnotation, t.GetName()))
+		}
+	}
+
+	if pvc, ok := annotations[pvcAnnotation]; ok {
+		dst.Spec.PersistentVolumeClaims = &capsulev1beta1.PersistentVolumeClaimsSpec{}
+		if err := json.Unmarshal([]byte(pvc), dst.Spec.PersistentVolumeClaims); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on tenant %s", pvcAnnotation, t.GetName()))
+		}
+	}
+
 	// Status
 	dst.Status = capsulev1beta1.TenantStatus{
 		Size:       t.Status.Size,
@@ -275,8 +412,22 @@ func (t *Tenant) ConvertTo(dstRaw conversion.Hub) error {
 	delete(dst.ObjectMeta.Annotations, podAllowedImagePullPolicyAnnotation)
 	delete(dst.ObjectMeta.Annotations, podPriorityAllowedAnnotation)
 	delete(dst.ObjectMeta.Annotations, podPriorityAllowedRegexAnnotation)
+	delete(dst.ObjectMeta.Annotations, podPriorityDefaultAnnotation)
+	delete(dst.ObjectMeta.Annotations, podPriorityRequiredAnnotation)
+	delete(dst.ObjectMeta.Annotations, storageClassDefaultAnnotation)
+	delete(dst.ObjectMeta.Annotations, storageClassRequiredAnnotation)
+	delete(dst.ObjectMeta.Annotations, ingressClassDefaultAnnotation)
+	delete(dst.ObjectMeta.Annotations, ingressClassRequiredAnnotation)
+	delete(dst.ObjectMeta.Annotations, resourceQuotaNamespaceScopedAnnotation)
+	delete(dst.ObjectMeta.Annotations, deletionPolicyAnnotation)
+	delete(dst.ObjectMeta.Annotations, namespaceAdoptionAnnotation)
+	delete(dst.ObjectMeta.Annotations, containerImagesAnnotation)
+	delete(dst.ObjectMeta.Annotations, pvcAnnotation)
 	delete(dst.ObjectMeta.Annotations, enableNodePortsAnnotation)
 	delete(dst.ObjectMeta.Annotations, enableExternalNameAnnotation)
+	delete(dst.ObjectMeta.Annotations, enableLoadBalancerAnnotation)
+	delete(dst.ObjectMeta.Annotations, loadBalancersAnnotation)
+	delete(dst.ObjectMeta.Annotations, nodePortsAnnotation)
 	delete(dst.ObjectMeta.Annotations, ownerGroupsAnnotation)
 	delete(dst.ObjectMeta.Annotations, ownerUsersAnnotation)
 	delete(dst.ObjectMeta.Annotations, ownerServiceAccountAnnotation)
@@ -426,12 +577,24 @@ func (t *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
 			Exact: src.Spec.StorageClasses.Exact,
 			Regex: src.Spec.StorageClasses.Regex,
 		}
+		if src.Spec.StorageClasses.Default != "" {
+			t.Annotations[storageClassDefaultAnnotation] = src.Spec.StorageClasses.Default
+		}
+		if src.Spec.StorageClasses.Required {
+			t.Annotations[storageClassRequiredAnnotation] = strconv.FormatBool(src.Spec.StorageClasses.Required)
+		}
 	}
 	if src.Spec.IngressClasses != nil {
 		t.Spec.IngressClasses = &AllowedListSpec{
 			Exact: src.Spec.IngressClasses.Exact,
 			Regex: src.Spec.IngressClasses.Regex,
 		}
+		if src.Spec.IngressClasses.Default != "" {
+			t.Annotations[ingressClassDefaultAnnotation] = src.Spec.IngressClasses.Default
+		}
+		if src.Spec.IngressClasses.Required {
+			t.Annotations[ingressClassRequiredAnnotation] = strconv.FormatBool(src.Spec.IngressClasses.Required)
+		}
 	}
 	if src.Spec.IngressHostnames != nil {
 		t.Spec.IngressHostnames = &AllowedListSpec{
@@ -452,7 +615,17 @@ func (t *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
 		t.Spec.LimitRanges = src.Spec.LimitRanges.Items
 	}
 	if src.Spec.ResourceQuota != nil {
-		t.Spec.ResourceQuota = src.Spec.ResourceQuota.Items
+		var namespaceScoped []string
+		for i, item := range src.Spec.ResourceQuota.Items {
+			t.Spec.ResourceQuota = append(t.Spec.ResourceQuota, item.ResourceQuotaSpec)
+
+			if !item.IsTenantScoped() {
+				namespaceScoped = append(namespaceScoped, strconv.Itoa(i))
+			}
+		}
+		if len(namespaceScoped) > 0 {
+			t.Annotations[resourceQuotaNamespaceScopedAnnotation] = strings.Join(namespaceScoped, ",")
+		}
 	}
 	if len(src.Spec.AdditionalRoleBindings) > 0 {
 		for _, rb := range src.Spec.AdditionalRoleBindings {
@@ -486,11 +659,64 @@ func (t *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
 		if src.Spec.PriorityClasses.Regex != "" {
 			t.Annotations[podPriorityAllowedRegexAnnotation] = src.Spec.PriorityClasses.Regex
 		}
+		if src.Spec.PriorityClasses.Default != "" {
+			t.Annotations[podPriorityDefaultAnnotation] = src.Spec.PriorityClasses.Default
+		}
+		if src.Spec.PriorityClasses.Required {
+			t.Annotations[podPriorityRequiredAnnotation] = strconv.FormatBool(src.Spec.PriorityClasses.Required)
+		}
 	}
 
 	if src.Spec.ServiceOptions != nil && src.Spec.ServiceOptions.AllowedServices != nil {
 		t.Annotations[enableNodePortsAnnotation] = strconv.FormatBool(*src.Spec.ServiceOptions.AllowedServices.NodePort)
 		t.Annotations[enableExternalNameAnnotation] = strconv.FormatBool(*src.Spec.ServiceOptions.AllowedServices.ExternalName)
+		if src.Spec.ServiceOptions.AllowedServices.LoadBalancer != nil {
+			t.Annotations[enableLoadBalancerAnnotation] = strconv.FormatBool(*src.Spec.ServiceOptions.AllowedServices.LoadBalancer)
+		}
+	}
+
+	if src.Spec.ServiceOptions != nil && src.Spec.ServiceOptions.LoadBalancers != nil {
+		loadBalancers, err := json.Marshal(src.Spec.ServiceOptions.LoadBalancers)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to serialize the load balancers constraints of tenant %s", src.GetName()))
+		}
+		t.Annotations[loadBalancersAnnotation] = string(loadBalancers)
+	}
+
+	if src.Spec.ServiceOptions != nil && src.Spec.ServiceOptions.NodePorts != nil {
+		nodePorts, err := json.Marshal(src.Spec.ServiceOptions.NodePorts)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to serialize the node ports of tenant %s", src.GetName()))
+		}
+		t.Annotations[nodePortsAnnotation] = string(nodePorts)
+	}
+
+	if len(src.Spec.DeletionPolicy) > 0 {
+		t.Annotations[deletionPolicyAnnotation] = src.Spec.DeletionPolicy.String()
+	}
+
+	if src.Spec.NamespaceAdoption != nil {
+		adoption, err := json.Marshal(src.Spec.NamespaceAdoption)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to serialize the namespace adoption of tenant %s", src.GetName()))
+		}
+		t.Annotations[namespaceAdoptionAnnotation] = string(adoption)
+	}
+
+	if src.Spec.ContainerImages != nil {
+		images, err := json.Marshal(src.Spec.ContainerImages)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to serialize the container images policy of tenant %s", src.GetName()))
+		}
+		t.Annotations[containerImagesAnnotation] = string(images)
+	}
+
+	if src.Spec.PersistentVolumeClaims != nil {
+		pvc, err := json.Marshal(src.Spec.PersistentVolumeClaims)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to serialize the persistent volume claims constraints of tenant %s", src.GetName()))
+		}
+		t.Annotations[pvcAnnotation] = string(pvc)
 	}
 
 	// Status
@@ -501,3 +727,79 @@ func (t *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
 
 	return nil
 }
+
+func (c *CapsuleConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*capsulev1beta1.CapsuleConfiguration)
+
+	// ObjectMeta
+	dst.ObjectMeta = c.ObjectMeta
+
+	// Spec
+	dst.Spec = capsulev1beta1.CapsuleConfigurationSpec{
+		UserGroups:                           c.Spec.UserGroups,
+		ForceTenantPrefix:                    c.Spec.ForceTenantPrefix,
+		ProtectedNamespaceRegexpString:       c.Spec.ProtectedNamespaceRegexpString,
+		AllowTenantIngressHostnamesCollision: c.Spec.AllowTenantIngressHostnamesCollision,
+		AllowIngressHostnameCollision:        c.Spec.AllowIngressHostnameCollision,
+	}
+
+	annotations := c.GetAnnotations()
+
+	if maxLength, ok := annotations[tenantNameMaxLengthAnnotation]; ok {
+		val, err := strconv.ParseInt(maxLength, 10, 32)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to parse %s annotation on capsuleconfiguration %s", tenantNameMaxLengthAnnotation, c.GetName()))
+		}
+		dst.Spec.TenantNameMaxLength = int32(val)
+	}
+
+	if reserved, ok := annotations[reservedTenantNamesAnnotation]; ok && len(reserved) > 0 {
+		dst.Spec.ReservedTenantNames = strings.Split(reserved, ",")
+	}
+
+	if nodePortRange, ok := annotations[nodePortRangeAnnotation]; ok {
+		dst.Spec.NodePortRange = nodePortRange
+	}
+
+	delete(dst.ObjectMeta.Annotations, tenantNameMaxLengthAnnotation)
+	delete(dst.ObjectMeta.Annotations, reservedTenantNamesAnnotation)
+	delete(dst.ObjectMeta.Annotations, nodePortRangeAnnotation)
+
+	return nil
+}
+
+func (c *CapsuleConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*capsulev1beta1.CapsuleConfiguration)
+
+	// ObjectMeta
+	c.ObjectMeta = src.ObjectMeta
+
+	// Spec
+	c.Spec = CapsuleConfigurationSpec{
+		UserGroups:                           src.Spec.UserGroups,
+		ForceTenantPrefix:                    src.Spec.ForceTenantPrefix,
+		ProtectedNamespaceRegexpString:       src.Spec.ProtectedNamespaceRegexpString,
+		AllowTenantIngressHostnamesCollision: src.Spec.AllowTenantIngressHostnamesCollision,
+		AllowIngressHostnameCollision:        src.Spec.AllowIngressHostnameCollision,
+	}
+
+	if src.Spec.TenantNameMaxLength > 0 || len(src.Spec.ReservedTenantNames) > 0 || len(src.Spec.NodePortRange) > 0 {
+		if c.Annotations == nil {
+			c.Annotations = make(map[string]string)
+		}
+	}
+
+	if src.Spec.TenantNameMaxLength > 0 {
+		c.Annotations[tenantNameMaxLengthAnnotation] = strconv.Itoa(int(src.Spec.TenantNameMaxLength))
+	}
+
+	if len(src.Spec.ReservedTenantNames) > 0 {
+		c.Annotations[reservedTenantNamesAnnotation] = strings.Join(src.Spec.ReservedTenantNames, ",")
+	}
+
+	if len(src.Spec.NodePortRange) > 0 {
+		c.Annotations[nodePortRangeAnnotation] = src.Spec.NodePortRange
+	}
+
+	return nil
+}
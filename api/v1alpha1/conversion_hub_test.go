@@ -20,6 +20,7 @@ import (
 
 func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 	var namespaceQuota int32 = 5
+	var maxSize = resource.MustParse("10Gi")
 	var nodeSelector = map[string]string{
 		"foo": "bar",
 	}
@@ -48,10 +49,20 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 		AllowedServices: &capsulev1beta1.AllowedServices{
 			NodePort:     pointer.BoolPtr(false),
 			ExternalName: pointer.BoolPtr(false),
+			LoadBalancer: pointer.BoolPtr(true),
 		},
 		ExternalServiceIPs: &capsulev1beta1.ExternalServiceIPsSpec{
 			Allowed: []capsulev1beta1.AllowedIP{"192.168.0.1"},
 		},
+		LoadBalancers: &capsulev1beta1.LoadBalancerSpec{
+			AllowedClasses:      &capsulev1beta1.AllowedListSpec{Exact: []string{"internal"}},
+			MaxCount:            pointer.Int32Ptr(2),
+			AllowedSourceRanges: []capsulev1beta1.AllowedIP{"10.0.0.0/8"},
+		},
+		NodePorts: &capsulev1beta1.NodePortsSpec{
+			Ranges: []capsulev1beta1.NodePortRange{{From: 30000, To: 30099}},
+			Ports:  []int32{31000},
+		},
 	}
 	var v1beta1AllowedListSpec = &capsulev1beta1.AllowedListSpec{
 		Exact: []string{"foo", "bar"},
@@ -111,6 +122,11 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 				corev1.ResourceQuotaScopeNotTerminating,
 			},
 		},
+		{
+			Hard: map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceServicesLoadBalancers: resource.MustParse("2"),
+			},
+		},
 	}
 
 	var v1beta1Tnt = capsulev1beta1.Tenant{
@@ -225,11 +241,17 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 					},
 				},
 			},
-			NamespaceQuota:      &namespaceQuota,
-			NamespacesMetadata:  v1beta1AdditionalMetadataSpec,
-			ServiceOptions:      v1beta1ServiceOptions,
-			StorageClasses:      v1beta1AllowedListSpec,
-			IngressClasses:      v1beta1AllowedListSpec,
+			NamespaceQuota:     &namespaceQuota,
+			NamespacesMetadata: v1beta1AdditionalMetadataSpec,
+			ServiceOptions:     v1beta1ServiceOptions,
+			StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: *v1beta1AllowedListSpec,
+				Default:         "foo",
+			},
+			IngressClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: *v1beta1AllowedListSpec,
+				Required:        true,
+			},
 			IngressHostnames:    v1beta1AllowedListSpec,
 			ContainerRegistries: v1beta1AllowedListSpec,
 			NodeSelector:        nodeSelector,
@@ -240,7 +262,16 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 				Items: limitRanges,
 			},
 			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{
-				Items: resourceQuotas,
+				Items: []capsulev1beta1.ResourceQuotaItem{
+					{
+						Scope:             capsulev1beta1.ResourceQuotaScopeTenant,
+						ResourceQuotaSpec: resourceQuotas[0],
+					},
+					{
+						Scope:             capsulev1beta1.ResourceQuotaScopeNamespace,
+						ResourceQuotaSpec: resourceQuotas[1],
+					},
+				},
 			},
 			AdditionalRoleBindings: []capsulev1beta1.AdditionalRoleBindingsSpec{
 				{
@@ -255,9 +286,28 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 				},
 			},
 			ImagePullPolicies: []capsulev1beta1.ImagePullPolicySpec{"Always", "IfNotPresent"},
-			PriorityClasses: &capsulev1beta1.AllowedListSpec{
-				Exact: []string{"default"},
-				Regex: "^tier-.*$",
+			PriorityClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"default"},
+					Regex: "^tier-.*$",
+				},
+				Default:  "default",
+				Required: true,
+			},
+			DeletionPolicy: capsulev1beta1.DeletionPolicyOrphan,
+			NamespaceAdoption: &capsulev1beta1.NamespaceAdoptionSpec{
+				Namespaces: []string{"legacy"},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "oil"},
+				},
+				DryRun: true,
+			},
+			ContainerImages: &capsulev1beta1.ContainerImagesSpec{
+				RequireDigest: true,
+			},
+			PersistentVolumeClaims: &capsulev1beta1.PersistentVolumeClaimsSpec{
+				MaxSize:        &maxSize,
+				AllowExpansion: pointer.BoolPtr(false),
 			},
 		},
 		Status: capsulev1beta1.TenantStatus{
@@ -274,24 +324,36 @@ func generateTenantsSpecs() (Tenant, capsulev1beta1.Tenant) {
 				"foo": "bar",
 			},
 			Annotations: map[string]string{
-				"foo":                                "bar",
-				podAllowedImagePullPolicyAnnotation:  "Always,IfNotPresent",
-				enableExternalNameAnnotation:         "false",
-				enableNodePortsAnnotation:            "false",
-				podPriorityAllowedAnnotation:         "default",
-				podPriorityAllowedRegexAnnotation:    "^tier-.*$",
-				ownerGroupsAnnotation:                "owner-foo,owner-bar",
-				ownerUsersAnnotation:                 "bob,jack",
-				ownerServiceAccountAnnotation:        "system:serviceaccount:oil-production:default,system:serviceaccount:gas-production:gas",
-				enableNodeUpdateAnnotation:           "alice,system:serviceaccount:oil-production:default",
-				enableNodeDeletionAnnotation:         "alice,jack",
-				enableStorageClassListingAnnotation:  "bob,jack",
-				enableStorageClassUpdateAnnotation:   "alice,system:serviceaccount:gas-production:gas",
-				enableStorageClassDeletionAnnotation: "alice,owner-bar",
-				enableIngressClassListingAnnotation:  "alice,owner-foo,owner-bar",
-				enableIngressClassUpdateAnnotation:   "alice,bob",
-				enableIngressClassDeletionAnnotation: "alice,jack",
-				enablePriorityClassListingAnnotation: "jack",
+				"foo":                                  "bar",
+				podAllowedImagePullPolicyAnnotation:    "Always,IfNotPresent",
+				enableExternalNameAnnotation:           "false",
+				enableLoadBalancerAnnotation:           "true",
+				nodePortsAnnotation:                    `{"ranges":[{"from":30000,"to":30099}],"ports":[31000]}`,
+				loadBalancersAnnotation:                `{"allowedClasses":{"allowed":["internal"]},"maxCount":2,"allowedSourceRanges":["10.0.0.0/8"]}`,
+				resourceQuotaNamespaceScopedAnnotation: "1",
+				deletionPolicyAnnotation:               "Orphan",
+				namespaceAdoptionAnnotation:            `{"namespaces":["legacy"],"selector":{"matchLabels":{"team":"oil"}},"dryRun":true}`,
+				containerImagesAnnotation:              `{"requireDigest":true}`,
+				pvcAnnotation:                          `{"maxSize":"10Gi","allowExpansion":false}`,
+				enableNodePortsAnnotation:              "false",
+				podPriorityAllowedAnnotation:           "default",
+				podPriorityAllowedRegexAnnotation:      "^tier-.*$",
+				podPriorityDefaultAnnotation:           "default",
+				podPriorityRequiredAnnotation:          "true",
+				storageClassDefaultAnnotation:          "foo",
+				ingressClassRequiredAnnotation:         "true",
+				ownerGroupsAnnotation:                  "owner-foo,owner-bar",
+				ownerUsersAnnotation:                   "bob,jack",
+				ownerServiceAccountAnnotation:          "system:serviceaccount:oil-production:default,system:serviceaccount:gas-production:gas",
+				enableNodeUpdateAnnotation:             "alice,system:serviceaccount:oil-production:default",
+				enableNodeDeletionAnnotation:           "alice,jack",
+				enableStorageClassListingAnnotation:    "bob,jack",
+				enableStorageClassUpdateAnnotation:     "alice,system:serviceaccount:gas-production:gas",
+				enableStorageClassDeletionAnnotation:   "alice,owner-bar",
+				enableIngressClassListingAnnotation:    "alice,owner-foo,owner-bar",
+				enableIngressClassUpdateAnnotation:     "alice,bob",
+				enableIngressClassDeletionAnnotation:   "alice,jack",
+				enablePriorityClassListingAnnotation:   "jack",
 			},
 		},
 		Spec: TenantSpec{
@@ -371,3 +433,81 @@ func TestConversionHub_ConvertFrom(t *testing.T) {
 		assert.EqualValues(t, v1alpha1Tnt, v1alpha1ConvertedTnt)
 	}
 }
+
+func generateCapsuleConfigurationSpecs() (CapsuleConfiguration, capsulev1beta1.CapsuleConfiguration) {
+	var v1beta1Cfg = capsulev1beta1.CapsuleConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			Annotations: map[string]string{
+				"foo": "bar",
+			},
+		},
+		Spec: capsulev1beta1.CapsuleConfigurationSpec{
+			UserGroups:                           []string{"capsule.clastix.io", "oil-users"},
+			ForceTenantPrefix:                    true,
+			ProtectedNamespaceRegexpString:       "^kube-.*$",
+			AllowTenantIngressHostnamesCollision: true,
+			AllowIngressHostnameCollision:        false,
+			TenantNameMaxLength:                  40,
+			ReservedTenantNames:                  []string{"default", "system"},
+			NodePortRange:                        "30000-32767",
+		},
+	}
+
+	var v1alpha1Cfg = CapsuleConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+			Annotations: map[string]string{
+				"foo":                         "bar",
+				tenantNameMaxLengthAnnotation: "40",
+				reservedTenantNamesAnnotation: "default,system",
+				nodePortRangeAnnotation:       "30000-32767",
+			},
+		},
+		Spec: CapsuleConfigurationSpec{
+			UserGroups:                           []string{"capsule.clastix.io", "oil-users"},
+			ForceTenantPrefix:                    true,
+			ProtectedNamespaceRegexpString:       "^kube-.*$",
+			AllowTenantIngressHostnamesCollision: true,
+			AllowIngressHostnameCollision:        false,
+		},
+	}
+
+	return v1alpha1Cfg, v1beta1Cfg
+}
+
+func TestConversionHub_CapsuleConfigurationConvertTo(t *testing.T) {
+	var v1beta1ConvertedCfg = capsulev1beta1.CapsuleConfiguration{}
+
+	v1alpha1Cfg, v1beta1Cfg := generateCapsuleConfigurationSpecs()
+	err := v1alpha1Cfg.ConvertTo(&v1beta1ConvertedCfg)
+	if assert.NoError(t, err) {
+		assert.Equal(t, v1beta1Cfg, v1beta1ConvertedCfg)
+	}
+}
+
+func TestConversionHub_CapsuleConfigurationConvertFrom(t *testing.T) {
+	var v1alpha1ConvertedCfg = CapsuleConfiguration{}
+
+	v1alpha1Cfg, v1beta1Cfg := generateCapsuleConfigurationSpecs()
+	err := v1alpha1ConvertedCfg.ConvertFrom(&v1beta1Cfg)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, v1alpha1Cfg, v1alpha1ConvertedCfg)
+	}
+}
+
+func TestConversionHub_CapsuleConfigurationRoundTrip(t *testing.T) {
+	var v1beta1ConvertedCfg = capsulev1beta1.CapsuleConfiguration{}
+	var v1alpha1ConvertedCfg = CapsuleConfiguration{}
+
+	v1alpha1Cfg, _ := generateCapsuleConfigurationSpecs()
+	if assert.NoError(t, v1alpha1Cfg.ConvertTo(&v1beta1ConvertedCfg)) && assert.NoError(t, v1alpha1ConvertedCfg.ConvertFrom(&v1beta1ConvertedCfg)) {
+		assert.EqualValues(t, v1alpha1Cfg, v1alpha1ConvertedCfg)
+	}
+}
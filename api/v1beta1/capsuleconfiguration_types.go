@@ -0,0 +1,84 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CapsuleConfigurationSpec defines the Capsule configuration
+type CapsuleConfigurationSpec struct {
+	// Names of the groups for Capsule users.
+	//+kubebuilder:default={capsule.clastix.io}
+	UserGroups []string `json:"userGroups,omitempty"`
+	// Enforces the Tenant owner, during Namespace creation, to name it using the selected Tenant name as prefix,
+	// separated by a dash. This is useful to avoid Namespace name collision in a public CaaS environment.
+	//+kubebuilder:default=false
+	ForceTenantPrefix bool `json:"forceTenantPrefix,omitempty"`
+	// Disallow creation of namespaces, whose name matches this regexp
+	ProtectedNamespaceRegexpString string `json:"protectedNamespaceRegex,omitempty"`
+	// When defining the exact match for allowed Ingress hostnames at Tenant level, a collision is not allowed.
+	// Toggling this, Capsule will not check if a hostname collision is in place, allowing the creation of
+	// two or more Tenant resources although sharing the same allowed hostname(s).
+	//
+	// The JSON path of the resource is: /spec/ingressHostnames/allowed
+	AllowTenantIngressHostnamesCollision bool `json:"allowTenantIngressHostnamesCollision,omitempty"`
+	// Allow the collision of Ingress resource hostnames across all the Tenants.
+	//+kubebuilder:default=true
+	AllowIngressHostnameCollision bool `json:"allowIngressHostnameCollision,omitempty"`
+	// Maximum length of the Tenant names, checked upon Tenant creation: it cannot be longer than 63 characters, since
+	// the Tenant name is used as label value. The limit applies to the Tenant name only, not to the objects replicated
+	// in the Tenant Namespaces, such as capsule-<tenant>-<index>, whose names can be up to 253 characters long.
+	// When unset, or 0, the default is applied.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=63
+	//+kubebuilder:default=63
+	TenantNameMaxLength int32 `json:"tenantNameMaxLength,omitempty"`
+	// Names that cannot be used for new Tenants, such as the ones colliding with system components.
+	ReservedTenantNames []string `json:"reservedTenantNames,omitempty"`
+	// The node port range of the cluster, as set with the --service-node-port-range flag of the API Server:
+	// the node ports allowed for the Tenants must be contained in it.
+	//+kubebuilder:default="30000-32767"
+	NodePortRange string `json:"nodePortRange,omitempty"`
+}
+
+// CapsuleConfigurationStatus defines the observed state of the Capsule configuration
+type CapsuleConfigurationStatus struct {
+	// The generation of the CapsuleConfiguration last processed by Capsule.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describing the validation result of the CapsuleConfiguration: an invalid configuration
+	// is not applied, and Capsule keeps running with the last valid one.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="The validation result of the configuration"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// CapsuleConfiguration is the Schema for the Capsule configuration API
+type CapsuleConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CapsuleConfigurationSpec   `json:"spec,omitempty"`
+	Status CapsuleConfigurationStatus `json:"status,omitempty"`
+}
+
+func (c *CapsuleConfiguration) Hub() {}
+
+//+kubebuilder:object:root=true
+
+// CapsuleConfigurationList contains a list of CapsuleConfiguration
+type CapsuleConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapsuleConfiguration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CapsuleConfiguration{}, &CapsuleConfigurationList{})
+}
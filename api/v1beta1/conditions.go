@@ -0,0 +1,28 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+const (
+	// ReadyCondition reports whether the resource has been processed successfully by Capsule.
+	ReadyCondition = "Ready"
+
+	// ValidReason is used when the CapsuleConfiguration has been validated and applied.
+	ValidReason = "Valid"
+	// InvalidReason is used when the CapsuleConfiguration has been rejected by validation.
+	InvalidReason = "Invalid"
+)
+
+const (
+	NamespacesSyncedCondition      = "NamespacesSynced"
+	NetworkPoliciesSyncedCondition = "NetworkPoliciesSynced"
+	LimitRangesSyncedCondition     = "LimitRangesSynced"
+	QuotaSyncedCondition           = "QuotaSynced"
+	RBACSyncedCondition            = "RBACSynced"
+	NamespacesAdoptedCondition     = "NamespacesAdopted"
+
+	// SyncedReason is used when all the Tenant resources of a kind have been synchronized.
+	SyncedReason = "Synced"
+	// SyncFailedReason is used when one or more Tenant resources couldn't be synchronized.
+	SyncFailedReason = "SyncFailed"
+)
@@ -0,0 +1,15 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+type ContainerImagesSpec struct {
+	// Requires the container images to be pinned by digest, such as registry.tld/app@sha256:... Optional.
+	RequireDigest bool `json:"requireDigest,omitempty"`
+	// Forbids the container images using the latest tag, or no tag at all, unless they are pinned by digest. Optional.
+	ForbidLatestTag bool `json:"forbidLatestTag,omitempty"`
+	// Specifies the registries, or repository prefixes, the container images are rewritten from, mapped to the ones they are rewritten to, such as docker.io to registry.internal/dockerhub-mirror. The longest matching prefix is rewritten. Optional.
+	RegistryRewrites map[string]string `json:"registryRewrites,omitempty"`
+	// Specifies the image pull policy set to the Pod containers not specifying one, or specifying one not allowed by the imagePullPolicies option: rather than rejecting the Pod, Capsule mutates it. Optional.
+	DefaultPullPolicy ImagePullPolicySpec `json:"defaultPullPolicy,omitempty"`
+}
@@ -0,0 +1,17 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+type DefaultAllowedListSpec struct {
+	AllowedListSpec `json:",inline"`
+	// Specifies the class Capsule sets to the resources not specifying one. Optional.
+	Default string `json:"default,omitempty"`
+	// Requires the resources to specify a class: the ones not specifying it are rejected, rather than getting the default class. Optional.
+	Required bool `json:"required,omitempty"`
+}
+
+// IsDefaulted returns true when the resources not specifying a class must get the default one.
+func (in *DefaultAllowedListSpec) IsDefaulted() bool {
+	return len(in.Default) > 0 && !in.Required
+}
@@ -0,0 +1,24 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// +kubebuilder:validation:Enum=Cascade;Orphan;Deny
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyCascade deletes the Tenant Namespaces along with the Tenant.
+	DeletionPolicyCascade DeletionPolicy = "Cascade"
+	// DeletionPolicyOrphan detaches the Tenant Namespaces, keeping them upon the Tenant deletion.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyDeny blocks the Tenant deletion as long as the Tenant has Namespaces.
+	DeletionPolicyDeny DeletionPolicy = "Deny"
+
+	// OrphanNamespacesFinalizer is put on the Tenant resources with the Orphan deletion policy,
+	// letting Capsule remove the Tenant owner reference from the Namespaces before the garbage collection.
+	OrphanNamespacesFinalizer = "capsule.clastix.io/orphan-namespaces"
+)
+
+func (p DeletionPolicy) String() string {
+	return string(p)
+}
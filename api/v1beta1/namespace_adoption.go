@@ -0,0 +1,29 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NamespaceAdoptionSpec struct {
+	// Names of the pre-existing Namespaces to adopt. Optional.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Label selector of the pre-existing Namespaces to adopt. Optional.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// When enabled, the Namespaces are not adopted, and the changes the adoption would perform are reported
+	// in the Tenant status. Optional.
+	//+kubebuilder:default=false
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// NamespaceAdoptionReport describes the adoption of a pre-existing Namespace.
+type NamespaceAdoptionReport struct {
+	// Name of the Namespace.
+	Namespace string `json:"namespace"`
+	// Whether the Namespace can be adopted by the Tenant.
+	Adoptable bool `json:"adoptable"`
+	// The changes performed by the adoption, or the reason why the Namespace cannot be adopted.
+	Changes []string `json:"changes,omitempty"`
+}
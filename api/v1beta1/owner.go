@@ -3,6 +3,13 @@
 
 package v1beta1
 
+import (
+	"fmt"
+	"strings"
+)
+
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
 type OwnerSpec struct {
 	// Kind of tenant owner. Possible values are "User", "Group", and "ServiceAccount"
 	Kind OwnerKind `json:"kind"`
@@ -12,6 +19,17 @@ type OwnerSpec struct {
 	ProxyOperations []ProxySettings `json:"proxySettings,omitempty"`
 }
 
+// ServiceAccountNamespacedName returns the Namespace and the name of a ServiceAccount owner,
+// whose name is the ServiceAccount username, such as system:serviceaccount:<namespace>:<name>.
+func (in OwnerSpec) ServiceAccountNamespacedName() (namespace, name string, err error) {
+	parts := strings.Split(strings.TrimPrefix(in.Name, serviceAccountUsernamePrefix), ":")
+	if !strings.HasPrefix(in.Name, serviceAccountUsernamePrefix) || len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("%s is not a ServiceAccount username, it must be in the form %s<namespace>:<name>", in.Name, serviceAccountUsernamePrefix)
+	}
+
+	return parts[0], parts[1], nil
+}
+
 // +kubebuilder:validation:Enum=User;Group;ServiceAccount
 type OwnerKind string
 
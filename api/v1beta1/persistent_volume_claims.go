@@ -0,0 +1,21 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type PersistentVolumeClaimsSpec struct {
+	// Specifies the maximum storage a PersistentVolumeClaim, or a PersistentVolumeClaim template, can request. Optional.
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	//+kubebuilder:default=true
+	// Specifies if the PersistentVolumeClaim resources can be expanded, increasing their storage request. Default is true. Optional.
+	AllowExpansion *bool `json:"allowExpansion,omitempty"`
+}
+
+// IsExpansionAllowed returns true unless the expansion of the PersistentVolumeClaim resources has been forbidden.
+func (in *PersistentVolumeClaimsSpec) IsExpansionAllowed() bool {
+	return in.AllowExpansion == nil || *in.AllowExpansion
+}
@@ -5,6 +5,30 @@ package v1beta1
 
 import corev1 "k8s.io/api/core/v1"
 
+// +kubebuilder:validation:Enum=Tenant;Namespace
+type ResourceQuotaScope string
+
+const (
+	ResourceQuotaScopeTenant    ResourceQuotaScope = "Tenant"
+	ResourceQuotaScopeNamespace ResourceQuotaScope = "Namespace"
+)
+
 type ResourceQuotaSpec struct {
-	Items []corev1.ResourceQuotaSpec `json:"items,omitempty"`
+	Items []ResourceQuotaItem `json:"items,omitempty"`
+}
+
+type ResourceQuotaItem struct {
+	// Define how the ResourceQuota item is enforced. With Tenant, the usage is aggregated across all the Tenant
+	// namespaces and the hard quota is never crossed by the Tenant as a whole. With Namespace, the item is copied
+	// verbatim in each Tenant namespace, like LimitRanges, and the hard quota applies to every namespace on its own.
+	// Optional, default to Tenant.
+	//+kubebuilder:default=Tenant
+	Scope ResourceQuotaScope `json:"scope,omitempty"`
+
+	corev1.ResourceQuotaSpec `json:",inline"`
+}
+
+// IsTenantScoped returns true when the item usage must be aggregated at Tenant level.
+func (in ResourceQuotaItem) IsTenantScoped() bool {
+	return in.Scope != ResourceQuotaScopeNamespace
 }
@@ -3,9 +3,82 @@
 
 package v1beta1
 
-// +kubebuilder:validation:Pattern="^([0-9]{1,3}.){3}[0-9]{1,3}(/([0-9]|[1-2][0-9]|3[0-2]))?$"
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// AllowedIP is either an IPv4 or IPv6 address, or a CIDR: the addresses are validated by the Tenant webhook.
 type AllowedIP string
 
+// Network returns the CIDR of the allowed IP: a bare IPv4 address is considered as a /32 CIDR, a bare IPv6 one as a /128.
+func (in AllowedIP) Network() (*net.IPNet, error) {
+	value := strings.TrimSpace(string(in))
+
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid CIDR", value)
+		}
+
+		return network, nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not a valid IP address", value)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
 type ExternalServiceIPsSpec struct {
 	Allowed []AllowedIP `json:"allowed"`
 }
+
+// Contains returns true if the given IP address is contained in one of the allowed CIDRs, regardless of the IP family.
+func (in *ExternalServiceIPsSpec) Contains(ip net.IP) bool {
+	return containsIP(in.Allowed, ip)
+}
+
+func containsIP(allowed []AllowedIP, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, a := range allowed {
+		network, err := a.Network()
+		if err != nil {
+			continue
+		}
+
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsNetwork(allowed []AllowedIP, cidr *net.IPNet) bool {
+	ones, bits := cidr.Mask.Size()
+
+	for _, a := range allowed {
+		network, err := a.Network()
+		if err != nil {
+			continue
+		}
+
+		allowedOnes, allowedBits := network.Mask.Size()
+		if allowedBits == bits && allowedOnes <= ones && network.Contains(cidr.IP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowedIP_Network(t *testing.T) {
+	for in, out := range map[AllowedIP]string{
+		"10.0.0.1":        "10.0.0.1/32",
+		"10.0.0.0/8":      "10.0.0.0/8",
+		"10.1.2.3/8":      "10.0.0.0/8",
+		"2001:db8::1":     "2001:db8::1/128",
+		"2001:db8::/32":   "2001:db8::/32",
+		" 192.168.0.1 ":   "192.168.0.1/32",
+		"::ffff:10.0.0.1": "10.0.0.1/32",
+	} {
+		network, err := in.Network()
+		assert.NoError(t, err)
+		assert.Equal(t, out, network.String())
+	}
+
+	for _, in := range []AllowedIP{"", "10.0.0", "10.0.0.0/33", "2001:db8::/129", "foo"} {
+		_, err := in.Network()
+		assert.Error(t, err)
+	}
+}
+
+func TestExternalServiceIPsSpec_Contains(t *testing.T) {
+	spec := ExternalServiceIPsSpec{
+		Allowed: []AllowedIP{"10.0.0.0/8", "192.168.0.1", "2001:db8::/32", "malformed"},
+	}
+
+	for _, ip := range []string{"10.1.2.3", "192.168.0.1", "2001:db8::1", "::ffff:10.0.0.1"} {
+		assert.True(t, spec.Contains(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"11.0.0.1", "192.168.0.2", "2001:db9::1", "::a00:1"} {
+		assert.False(t, spec.Contains(net.ParseIP(ip)), ip)
+	}
+
+	assert.False(t, spec.Contains(nil))
+	assert.False(t, (&ExternalServiceIPsSpec{}).Contains(net.ParseIP("10.0.0.1")))
+}
+
+func TestLoadBalancerSpec_ContainsSourceRange(t *testing.T) {
+	spec := LoadBalancerSpec{
+		AllowedSourceRanges: []AllowedIP{"10.0.0.0/8", "2001:db8::/32"},
+	}
+
+	for in, allowed := range map[string]bool{
+		"10.0.0.0/8":       true,
+		"10.1.0.0/16":      true,
+		"10.1.2.3/32":      true,
+		"0.0.0.0/0":        false,
+		"11.0.0.0/16":      false,
+		"2001:db8:1::/48":  true,
+		"2001:db8::/16":    false,
+		"::ffff:0.0.0.0/0": false,
+	} {
+		_, network, err := net.ParseCIDR(in)
+		assert.NoError(t, err)
+		assert.Equal(t, allowed, spec.ContainsSourceRange(network), in)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"net"
+)
+
+type LoadBalancerSpec struct {
+	// Specifies the allowed values for the loadBalancerClass field of the Services with type LoadBalancer: Services not specifying it are rejected. Optional.
+	AllowedClasses *AllowedListSpec `json:"allowedClasses,omitempty"`
+	//+kubebuilder:validation:Minimum=0
+	// Specifies the maximum number of Services with type LoadBalancer allowed for the Tenant, regardless of the namespace. Optional.
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// Specifies the IPv4 or IPv6 CIDRs the loadBalancerSourceRanges of the Services with type LoadBalancer must be contained in. Services not specifying them are rejected. Optional.
+	AllowedSourceRanges []AllowedIP `json:"allowedSourceRanges,omitempty"`
+}
+
+// ContainsSourceRange returns true if the given source range is contained in one of the allowed CIDRs of the same IP family.
+func (in *LoadBalancerSpec) ContainsSourceRange(sourceRange *net.IPNet) bool {
+	return containsNetwork(in.AllowedSourceRanges, sourceRange)
+}
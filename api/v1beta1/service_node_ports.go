@@ -0,0 +1,70 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+type NodePortRange struct {
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	// The first port of the range. Mandatory.
+	From int32 `json:"from"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	// The last port of the range, included. Mandatory.
+	To int32 `json:"to"`
+}
+
+type NodePortsSpec struct {
+	// Specifies the ranges of node ports allowed for the Tenant. Optional.
+	Ranges []NodePortRange `json:"ranges,omitempty"`
+	// Specifies the single node ports allowed for the Tenant. Optional.
+	Ports []int32 `json:"ports,omitempty"`
+}
+
+// Contains returns true if the given node port is allowed for the Tenant.
+func (in *NodePortsSpec) Contains(port int32) bool {
+	for _, p := range in.Ports {
+		if p == port {
+			return true
+		}
+	}
+
+	for _, r := range in.Ranges {
+		if port >= r.From && port <= r.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainsRange returns true if the given range is one of the ranges allowed for the Tenant.
+func (in *NodePortsSpec) ContainsRange(portRange NodePortRange) bool {
+	for _, r := range in.Ranges {
+		if r == portRange {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextCandidate returns the first node port allowed for the Tenant and not skipped, in the order they are assigned:
+// the single ports first, then the ranges. The scan stops at the first candidate, without expanding the ranges.
+func (in *NodePortsSpec) NextCandidate(skip func(port int32) bool) (int32, bool) {
+	for _, p := range in.Ports {
+		if !skip(p) {
+			return p, true
+		}
+	}
+
+	for _, r := range in.Ranges {
+		for p := r.From; p <= r.To; p++ {
+			if !skip(p) {
+				return p, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,50 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodePortsSpec_Contains(t *testing.T) {
+	spec := NodePortsSpec{
+		Ranges: []NodePortRange{{From: 30000, To: 30009}},
+		Ports:  []int32{31000},
+	}
+
+	for _, port := range []int32{30000, 30005, 30009, 31000} {
+		assert.True(t, spec.Contains(port))
+	}
+	for _, port := range []int32{29999, 30010, 31001} {
+		assert.False(t, spec.Contains(port))
+	}
+
+	assert.False(t, (&NodePortsSpec{}).Contains(30000))
+}
+
+func TestNodePortsSpec_NextCandidate(t *testing.T) {
+	spec := NodePortsSpec{
+		Ranges: []NodePortRange{{From: 30000, To: 30002}, {From: 30001, To: 30003}},
+		Ports:  []int32{31000, 30002},
+	}
+
+	taken := make(map[int32]bool)
+
+	var assigned []int32
+	for {
+		port, ok := spec.NextCandidate(func(port int32) bool { return taken[port] })
+		if !ok {
+			break
+		}
+		taken[port] = true
+		assigned = append(assigned, port)
+	}
+
+	assert.Equal(t, []int32{31000, 30002, 30000, 30001, 30003}, assigned)
+
+	_, ok := (&NodePortsSpec{}).NextCandidate(func(int32) bool { return false })
+	assert.False(t, ok)
+}
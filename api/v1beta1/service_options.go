@@ -8,6 +8,10 @@ type ServiceOptions struct {
 	AdditionalMetadata *AdditionalMetadataSpec `json:"additionalMetadata,omitempty"`
 	// Block or deny certain type of Services. Optional.
 	AllowedServices *AllowedServices `json:"allowedServices,omitempty"`
-	// Specifies the external IPs that can be used in Services with type ClusterIP. An empty list means no IPs are allowed. Optional.
+	// Specifies the IPv4 or IPv6 addresses, or CIDRs, that can be used as external IPs, load balancer IP, and load balancer ingress IPs of the Services. An empty list means no IPs are allowed. Optional.
 	ExternalServiceIPs *ExternalServiceIPsSpec `json:"externalIPs,omitempty"`
+	// Specifies the constraints on the Services with type LoadBalancer, such as the allowed classes, the maximum number, or the allowed source ranges. Optional.
+	LoadBalancers *LoadBalancerSpec `json:"loadBalancers,omitempty"`
+	// Specifies the node ports the Services of the Tenant can use: the Services not specifying them get a free one from the allowed ports. Optional.
+	NodePorts *NodePortsSpec `json:"nodePorts,omitempty"`
 }
@@ -14,6 +14,11 @@ const (
 	AvailableStorageClassesRegexpAnnotation = "capsule.clastix.io/storage-classes-regexp"
 	AllowedRegistriesAnnotation             = "capsule.clastix.io/allowed-registries"
 	AllowedRegistriesRegexpAnnotation       = "capsule.clastix.io/allowed-registries-regexp"
+	// AdoptedNamespaceAnnotation is put on the pre-existing Namespaces adopted by a Tenant, reporting its name.
+	AdoptedNamespaceAnnotation = "capsule.clastix.io/adopted-by"
+	// TransferredNamespaceAnnotation is put on the Namespaces transferred to a different Tenant, reporting the previous one:
+	// it's removed once the resources replicated for the previous Tenant have been pruned.
+	TransferredNamespaceAnnotation = "capsule.clastix.io/transferred-from"
 )
 
 func UsedQuotaFor(resource fmt.Stringer) string {
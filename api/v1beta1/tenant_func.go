@@ -16,6 +16,14 @@ func (t *Tenant) IsCordoned() bool {
 	return false
 }
 
+// GetDeletionPolicy returns the Tenant deletion policy, defaulting to Cascade when not set.
+func (t *Tenant) GetDeletionPolicy() DeletionPolicy {
+	if len(t.Spec.DeletionPolicy) == 0 {
+		return DeletionPolicyCascade
+	}
+	return t.Spec.DeletionPolicy
+}
+
 func (t *Tenant) IsFull() bool {
 	// we don't have limits on assigned Namespaces
 	if t.Spec.NamespaceQuota == nil {
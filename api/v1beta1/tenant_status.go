@@ -3,6 +3,11 @@
 
 package v1beta1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // +kubebuilder:validation:Enum=cordoned;active
 type tenantState string
 
@@ -20,4 +25,51 @@ type TenantStatus struct {
 	Size uint `json:"size"`
 	// List of namespaces assigned to the Tenant.
 	Namespaces []string `json:"namespaces,omitempty"`
+	// The generation of the Tenant last processed by Capsule.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions reporting the synchronization of the resources managed by the Tenant.
+	// Known condition types are "Ready", "NamespacesSynced", "NetworkPoliciesSynced", "LimitRangesSynced",
+	// "QuotaSynced", "RBACSynced", and "NamespacesAdopted".
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// List of the failures occurred during the last synchronization of the Tenant Namespaces.
+	FailedNamespaces []NamespaceFailure `json:"failedNamespaces,omitempty"`
+	// The Tenant-wide usage of each ResourceQuota item.
+	ResourceQuotas []TenantResourceQuotaStatus `json:"resourceQuotas,omitempty"`
+	// The Namespaces selected for adoption that have not been adopted: in dry-run mode, the changes the adoption
+	// would perform, otherwise the reason why the Namespace cannot be adopted.
+	NamespaceAdoption []NamespaceAdoptionReport `json:"namespaceAdoption,omitempty"`
+}
+
+// TenantResourceQuotaStatus reports the usage of a ResourceQuota item across all the Tenant Namespaces.
+type TenantResourceQuotaStatus struct {
+	// Index of the ResourceQuota item in the Tenant specification.
+	Index int `json:"index"`
+	// The enforced hard limits for the whole Tenant.
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+	// The resources used across all the Tenant Namespaces, including the ones reserved upon admission.
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// The last time resources have been reserved upon admission: the reservations performed recently
+	// are kept by the controller, until they are reflected in the usage of the ResourceQuota objects.
+	LastReservationTime *metav1.Time `json:"lastReservationTime,omitempty"`
+}
+
+// GetResourceQuotaStatus returns the status of the ResourceQuota item with the given index, if any.
+func (in *TenantStatus) GetResourceQuotaStatus(index int) *TenantResourceQuotaStatus {
+	for i := range in.ResourceQuotas {
+		if in.ResourceQuotas[i].Index == index {
+			return &in.ResourceQuotas[i]
+		}
+	}
+
+	return nil
+}
+
+// NamespaceFailure describes a resource that couldn't be synchronized in a Tenant Namespace.
+type NamespaceFailure struct {
+	// Name of the Namespace.
+	Namespace string `json:"namespace"`
+	// Kind of the resource that couldn't be synchronized.
+	Kind string `json:"kind"`
+	// The error occurred during the synchronization.
+	Message string `json:"message"`
 }
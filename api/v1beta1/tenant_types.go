@@ -19,28 +19,37 @@ type TenantSpec struct {
 	NamespacesMetadata *AdditionalMetadataSpec `json:"namespacesMetadata,omitempty"`
 	// Specifies options for the Service, such as additional metadata or block of certain type of Services. Optional.
 	ServiceOptions *ServiceOptions `json:"serviceOptions,omitempty"`
-	// Specifies the allowed StorageClasses assigned to the Tenant. Capsule assures that all PersistentVolumeClaim resources created in the Tenant can use only one of the allowed StorageClasses. Optional.
-	StorageClasses *AllowedListSpec `json:"storageClasses,omitempty"`
-	// Specifies the allowed IngressClasses assigned to the Tenant. Capsule assures that all Ingress resources created in the Tenant can use only one of the allowed IngressClasses. Optional.
-	IngressClasses *AllowedListSpec `json:"ingressClasses,omitempty"`
+	// Specifies the allowed StorageClasses assigned to the Tenant. Capsule assures that all PersistentVolumeClaim resources, and PersistentVolumeClaim templates of ephemeral volumes and StatefulSets, created in the Tenant can use only one of the allowed StorageClasses, setting the default one to the PersistentVolumeClaim resources not specifying it. Optional.
+	StorageClasses *DefaultAllowedListSpec `json:"storageClasses,omitempty"`
+	// Specifies the allowed IngressClasses assigned to the Tenant. Capsule assures that all Ingress resources created in the Tenant can use only one of the allowed IngressClasses, setting the default one to the Ingress resources not specifying it. Optional.
+	IngressClasses *DefaultAllowedListSpec `json:"ingressClasses,omitempty"`
+	// Specifies the constraints on the PersistentVolumeClaim resources, and templates, created in the Tenant, such as the maximum storage size, or the possibility to expand them. Optional.
+	PersistentVolumeClaims *PersistentVolumeClaimsSpec `json:"persistentVolumeClaims,omitempty"`
 	// Specifies the allowed hostnames in Ingresses for the given Tenant. Capsule assures that all Ingress resources created in the Tenant can use only one of the allowed hostnames. Optional.
 	IngressHostnames *AllowedListSpec `json:"ingressHostnames,omitempty"`
 	// Specifies the trusted Image Registries assigned to the Tenant. Capsule assures that all Pods resources created in the Tenant can use only one of the allowed trusted registries. Optional.
 	ContainerRegistries *AllowedListSpec `json:"containerRegistries,omitempty"`
+	// Specifies the policy the container image references must comply with, such as being pinned by digest, or not using the latest tag. Optional.
+	ContainerImages *ContainerImagesSpec `json:"containerImages,omitempty"`
 	// Specifies the label to control the placement of pods on a given pool of worker nodes. All namesapces created within the Tenant will have the node selector annotation. This annotation tells the Kubernetes scheduler to place pods on the nodes having the selector label. Optional.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// Specifies the NetworkPolicies assigned to the Tenant. The assigned NetworkPolicies are inherited by any namespace created in the Tenant. Optional.
 	NetworkPolicies *NetworkPolicySpec `json:"networkPolicies,omitempty"`
 	// Specifies the NetworkPolicies assigned to the Tenant. The assigned NetworkPolicies are inherited by any namespace created in the Tenant. Optional.
 	LimitRanges *LimitRangesSpec `json:"limitRanges,omitempty"`
-	// Specifies a list of ResourceQuota resources assigned to the Tenant. The assigned values are inherited by any namespace created in the Tenant. The Capsule operator aggregates ResourceQuota at Tenant level, so that the hard quota is never crossed for the given Tenant. This permits the Tenant owner to consume resources in the Tenant regardless of the namespace. Optional.
+	// Specifies a list of ResourceQuota resources assigned to the Tenant. The assigned values are inherited by any namespace created in the Tenant. Unless the item scope is Namespace, the Capsule operator aggregates ResourceQuota at Tenant level, so that the hard quota is never crossed for the given Tenant. This permits the Tenant owner to consume resources in the Tenant regardless of the namespace. Optional.
 	ResourceQuota *ResourceQuotaSpec `json:"resourceQuotas,omitempty"`
 	// Specifies additional RoleBindings assigned to the Tenant. Capsule will ensure that all namespaces in the Tenant always contain the RoleBinding for the given ClusterRole. Optional.
 	AdditionalRoleBindings []AdditionalRoleBindingsSpec `json:"additionalRoleBindings,omitempty"`
 	// Specify the allowed values for the imagePullPolicies option in Pod resources. Capsule assures that all Pod resources created in the Tenant can use only one of the allowed policy. Optional.
 	ImagePullPolicies []ImagePullPolicySpec `json:"imagePullPolicies,omitempty"`
-	// Specifies the allowed IngressClasses assigned to the Tenant. Capsule assures that all Ingress resources created in the Tenant can use only one of the allowed IngressClasses. Optional.
-	PriorityClasses *AllowedListSpec `json:"priorityClasses,omitempty"`
+	// Specifies the allowed PriorityClasses assigned to the Tenant. Capsule assures that all Pod resources created in the Tenant can use only one of the allowed PriorityClasses, setting the default one to the Pod resources not specifying it. Optional.
+	PriorityClasses *DefaultAllowedListSpec `json:"priorityClasses,omitempty"`
+	// Specifies what happens to the Tenant namespaces when the Tenant is deleted: with Cascade, the namespaces are deleted along with the Tenant, except the adopted ones which are detached; with Orphan, the namespaces are detached from the Tenant and kept; with Deny, the Tenant cannot be deleted as long as it has namespaces. Optional, default to Cascade.
+	//+kubebuilder:default=Cascade
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Specifies the pre-existing Namespaces the Tenant adopts, by name or label selector. Capsule sets the Tenant as controller of the selected Namespaces, then applies the Tenant resources to them. Namespaces controlled by another Tenant are not adopted. Optional.
+	NamespaceAdoption *NamespaceAdoptionSpec `json:"namespaceAdoption,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -51,6 +60,7 @@ type TenantSpec struct {
 // +kubebuilder:printcolumn:name="Namespace quota",type="integer",JSONPath=".spec.namespaceQuota",description="The max amount of Namespaces can be created"
 // +kubebuilder:printcolumn:name="Namespace count",type="integer",JSONPath=".status.size",description="The total amount of Namespaces in use"
 // +kubebuilder:printcolumn:name="Node selector",type="string",JSONPath=".spec.nodeSelector",description="Node Selector applied to Pods"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether all the Tenant resources have been synchronized"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 
 // Tenant is the Schema for the tenants API
@@ -7,7 +7,12 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
-	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,13 +20,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	capsulev1alpha1 "github.com/clastix/capsule/api/v1alpha1"
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 	"github.com/clastix/capsule/pkg/configuration"
 )
 
 type Manager struct {
-	Log    logr.Logger
-	Client client.Client
+	Log      logr.Logger
+	Client   client.Client
+	Recorder record.EventRecorder
 }
 
 // InjectClient injects the Client interface, required by the Runnable interface
@@ -54,20 +60,67 @@ func forOptionPerInstanceName(instanceName string) builder.ForOption {
 
 func (r *Manager) SetupWithManager(mgr ctrl.Manager, configurationName string) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capsulev1alpha1.CapsuleConfiguration{}, forOptionPerInstanceName(configurationName)).
+		For(&capsulev1beta1.CapsuleConfiguration{}, forOptionPerInstanceName(configurationName)).
 		Complete(r)
 }
 
+// Reconcile validates the CapsuleConfiguration, reporting the result in its status:
+// an invalid configuration is not applied, and Capsule keeps serving the last valid one.
 func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
 	r.Log.Info("CapsuleConfiguration reconciliation started", "request.name", request.Name)
 
-	cfg := configuration.NewCapsuleConfiguration(r.Client, request.Name)
-	// Validating the Capsule Configuration options
-	if _, err = cfg.ProtectedNamespaceRegexp(); err != nil {
-		panic(errors.Wrap(err, "Invalid configuration for protected Namespace regex"))
+	cfg := &capsulev1beta1.CapsuleConfiguration{}
+	if err = r.Client.Get(ctx, request.NamespacedName, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("Request object not found, could have been deleted after reconcile request")
+
+			return res, nil
+		}
+		r.Log.Error(err, "Error reading the object")
+
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    capsulev1beta1.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  capsulev1beta1.ValidReason,
+		Message: "The configuration has been applied",
+	}
+
+	if errs := configuration.Validate(cfg.Spec); len(errs) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = capsulev1beta1.InvalidReason
+		condition.Message = errs.ToAggregate().Error()
+
+		r.Log.Info("CapsuleConfiguration is invalid, keeping the last valid one", "errors", condition.Message)
+		r.Recorder.Eventf(cfg, corev1.EventTypeWarning, capsulev1beta1.InvalidReason, "Configuration is invalid and has not been applied: %s", condition.Message)
+	} else if !meta.IsStatusConditionTrue(cfg.Status.Conditions, capsulev1beta1.ReadyCondition) {
+		r.Recorder.Event(cfg, corev1.EventTypeNormal, capsulev1beta1.ValidReason, "Configuration has been applied")
+	}
+
+	if err = r.updateStatus(ctx, request.NamespacedName, cfg.GetGeneration(), condition); err != nil {
+		r.Log.Error(err, "Cannot update CapsuleConfiguration status")
+
+		return
 	}
 
 	r.Log.Info("CapsuleConfiguration reconciliation finished", "request.name", request.Name)
 
 	return
 }
+
+func (r *Manager) updateStatus(ctx context.Context, key client.ObjectKey, generation int64, condition metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		cfg := &capsulev1beta1.CapsuleConfiguration{}
+		if err = r.Client.Get(ctx, key, cfg); err != nil {
+			return
+		}
+
+		cfg.Status.ObservedGeneration = generation
+		condition.ObservedGeneration = generation
+		meta.SetStatusCondition(&cfg.Status.Conditions, condition)
+
+		return r.Client.Status().Update(ctx, cfg)
+	})
+}
@@ -6,12 +6,14 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -23,7 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	capsulev1alpha1 "github.com/clastix/capsule/api/v1alpha1"
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 	"github.com/clastix/capsule/pkg/configuration"
 )
 
@@ -79,11 +81,20 @@ func (r *Manager) SetupWithManager(mgr ctrl.Manager, configurationName string) (
 				return r.filterByNames(genericEvent.Object.GetName())
 			},
 		})).
-		Watches(source.NewKindWithCache(&capsulev1alpha1.CapsuleConfiguration{}, mgr.GetCache()), handler.Funcs{
+		Watches(source.NewKindWithCache(&capsulev1beta1.CapsuleConfiguration{}, mgr.GetCache()), handler.Funcs{
 			UpdateFunc: func(updateEvent event.UpdateEvent, limitingInterface workqueue.RateLimitingInterface) {
 				if updateEvent.ObjectNew.GetName() == configurationName {
-					if crbErr := r.EnsureClusterRoleBindings(); crbErr != nil {
-						r.Log.Error(err, "cannot update ClusterRoleBinding upon CapsuleConfiguration update")
+					// Using the groups from the event rather than the Configuration snapshot,
+					// since the latter is updated by another informer handler.
+					spec := updateEvent.ObjectNew.(*capsulev1beta1.CapsuleConfiguration).Spec
+					// An invalid configuration is not applied by the snapshot, neither by the ClusterRoleBinding.
+					if errs := configuration.Validate(spec); len(errs) > 0 {
+						r.Log.Error(errs.ToAggregate(), "CapsuleConfiguration is invalid, ClusterRoleBinding is left unchanged")
+
+						return
+					}
+					if crbErr := r.ensureClusterRoleBindings(spec.UserGroups); crbErr != nil {
+						r.Log.Error(crbErr, "cannot update ClusterRoleBinding upon CapsuleConfiguration update")
 					}
 				}
 			},
@@ -100,6 +111,9 @@ func (r *Manager) SetupWithManager(mgr ctrl.Manager, configurationName string) (
 func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
 	switch request.Name {
 	case ProvisionerRoleName:
+		if !r.Configuration.HasSynced() {
+			return reconcile.Result{RequeueAfter: time.Second}, nil
+		}
 		if err = r.EnsureClusterRole(ProvisionerRoleName); err != nil {
 			r.Log.Error(err, "Reconciliation for ClusterRole failed", "ClusterRole", ProvisionerRoleName)
 
@@ -119,7 +133,11 @@ func (r *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	return
 }
 
-func (r *Manager) EnsureClusterRoleBindings() (err error) {
+func (r *Manager) EnsureClusterRoleBindings() error {
+	return r.ensureClusterRoleBindings(r.Configuration.UserGroups())
+}
+
+func (r *Manager) ensureClusterRoleBindings(groups []string) (err error) {
 	crb := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: ProvisionerRoleName,
@@ -131,7 +149,7 @@ func (r *Manager) EnsureClusterRoleBindings() (err error) {
 
 		crb.Subjects = []rbacv1.Subject{}
 
-		for _, group := range r.Configuration.UserGroups() {
+		for _, group := range groups {
 			crb.Subjects = append(crb.Subjects, rbacv1.Subject{
 				Kind: "Group",
 				Name: group,
@@ -179,6 +197,14 @@ func (r *Manager) Start(ctx context.Context) error {
 		}
 	}
 
+	r.Log.Info("waiting for the CapsuleConfiguration")
+	// binding the default user groups would grant the Namespace provisioning to unexpected users
+	if err := wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		return r.Configuration.HasSynced(), nil
+	}, ctx.Done()); err != nil {
+		return err
+	}
+
 	r.Log.Info("setting up ClusterRoleBindings")
 	if err := r.EnsureClusterRoleBindings(); err != nil {
 		if errors.IsAlreadyExists(err) {
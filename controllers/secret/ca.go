@@ -42,11 +42,21 @@ func (r *CAReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // By default helm doesn't allow to use templates in CRD (https://helm.sh/docs/chart_best_practices/custom_resource_definitions/#method-1-let-helm-do-it-for-you).
 // In order to overcome this, we are setting conversion strategy in helm chart to None, and then update it with CA and namespace information.
 func (r *CAReconciler) UpdateCustomResourceDefinition(caBundle []byte) error {
+	for _, name := range []string{"tenants.capsule.clastix.io", "capsuleconfigurations.capsule.clastix.io"} {
+		if err := r.updateCustomResourceDefinition(name, caBundle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CAReconciler) updateCustomResourceDefinition(name string, caBundle []byte) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
 		crd := &apiextensionsv1.CustomResourceDefinition{}
-		err = r.Get(context.TODO(), types.NamespacedName{Name: "tenants.capsule.clastix.io"}, crd)
+		err = r.Get(context.TODO(), types.NamespacedName{Name: name}, crd)
 		if err != nil {
-			r.Log.Error(err, "cannot retrieve CustomResourceDefinition")
+			r.Log.Error(err, "cannot retrieve CustomResourceDefinition", "name", name)
 			return err
 		}
 
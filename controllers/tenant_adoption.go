@@ -0,0 +1,243 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// adoptNamespaces sets the Tenant as controller of the pre-existing Namespaces selected for adoption:
+// the Tenant resources are then applied by the following synchronization steps.
+// In dry-run mode, the Namespaces are left untouched, and the changes are only reported.
+func (r *TenantReconciler) adoptNamespaces(tenant *capsulev1beta1.Tenant) (reports []capsulev1beta1.NamespaceAdoptionReport, err error) {
+	candidates, err := r.adoptionCandidates(tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(tenant.Status.Namespaces)
+
+	var errs *multierror.Error
+
+	for _, ns := range candidates {
+		report := capsulev1beta1.NamespaceAdoptionReport{Namespace: ns.GetName()}
+
+		if owner := metav1.GetControllerOf(&ns); owner != nil {
+			if owner.UID == tenant.GetUID() {
+				continue
+			}
+			report.Changes = append(report.Changes, fmt.Sprintf("Namespace is already controlled by %s %s", owner.Kind, owner.Name))
+			reports = append(reports, report)
+
+			continue
+		}
+
+		if ns.Status.Phase != corev1.NamespaceActive {
+			report.Changes = append(report.Changes, "Namespace is terminating")
+			reports = append(reports, report)
+
+			continue
+		}
+
+		if reason := r.adoptionForbiddenReason(tenant, ns.GetName()); len(reason) > 0 {
+			report.Changes = append(report.Changes, reason)
+			reports = append(reports, report)
+
+			continue
+		}
+
+		if tenant.Spec.NamespaceQuota != nil && size >= int(*tenant.Spec.NamespaceQuota) {
+			report.Changes = append(report.Changes, "Namespace quota of the Tenant has been reached")
+			reports = append(reports, report)
+
+			continue
+		}
+
+		report.Adoptable = true
+		size++
+
+		if tenant.Spec.NamespaceAdoption.DryRun {
+			report.Changes = r.adoptionChanges(tenant)
+			reports = append(reports, report)
+
+			continue
+		}
+
+		if err = r.adoptNamespace(tenant, ns.GetName()); err != nil {
+			r.Log.Error(err, "Cannot adopt Namespace", "namespace", ns.GetName())
+			errs = multierror.Append(errs, newNamespaceError(ns.GetName(), "Namespace", err))
+
+			report.Adoptable = false
+			report.Changes = []string{err.Error()}
+			reports = append(reports, report)
+
+			continue
+		}
+
+		r.Log.Info("Namespace has been adopted", "namespace", ns.GetName())
+		r.Recorder.Eventf(tenant, corev1.EventTypeNormal, "NamespaceAdopted", "Namespace %s has been adopted by the Tenant", ns.GetName())
+	}
+
+	return reports, errs.ErrorOrNil()
+}
+
+// adoptionCandidates returns the Namespaces selected for adoption, sorted by name.
+func (r *TenantReconciler) adoptionCandidates(tenant *capsulev1beta1.Tenant) ([]corev1.Namespace, error) {
+	candidates := make(map[string]corev1.Namespace)
+
+	for _, name := range tenant.Spec.NamespaceAdoption.Namespaces {
+		ns := corev1.Namespace{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: name}, &ns); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		candidates[name] = ns
+	}
+
+	if tenant.Spec.NamespaceAdoption.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(tenant.Spec.NamespaceAdoption.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		nl := &corev1.NamespaceList{}
+		if err = r.List(context.TODO(), nl, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, ns := range nl.Items {
+			candidates[ns.GetName()] = ns
+		}
+	}
+
+	out := make([]corev1.Namespace, 0, len(candidates))
+	for _, ns := range candidates {
+		out = append(out, ns)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].GetName() < out[j].GetName()
+	})
+
+	return out, nil
+}
+
+// adoptionForbiddenReason returns why the Namespace cannot be adopted, regardless of its state: the Namespaces
+// of the cluster components, and the ones a Tenant owner couldn't create, are never assigned to a Tenant.
+func (r *TenantReconciler) adoptionForbiddenReason(tenant *capsulev1beta1.Tenant, name string) string {
+	switch name {
+	case metav1.NamespaceSystem, metav1.NamespacePublic, metav1.NamespaceDefault, corev1.NamespaceNodeLease, r.CapsuleNamespace:
+		return "Namespace is reserved to the cluster components"
+	}
+
+	if tenant.IsCordoned() {
+		return "Tenant is cordoned"
+	}
+
+	if r.Configuration == nil {
+		return ""
+	}
+
+	if exp, err := r.Configuration.ProtectedNamespaceRegexp(); err != nil || (exp != nil && exp.MatchString(name)) {
+		return "Namespace is protected by the Capsule configuration"
+	}
+
+	if r.Configuration.ForceTenantPrefix() && !strings.HasPrefix(name, fmt.Sprintf("%s-", tenant.GetName())) {
+		return fmt.Sprintf("Namespace name doesn't have the %s- prefix of the Tenant", tenant.GetName())
+	}
+
+	return ""
+}
+
+func (r *TenantReconciler) adoptNamespace(tenant *capsulev1beta1.Tenant, name string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		ns := &corev1.Namespace{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: name}, ns); err != nil {
+			return err
+		}
+
+		if err := controllerutil.SetControllerReference(tenant, ns, r.Scheme); err != nil {
+			return err
+		}
+
+		annotations := ns.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[capsulev1beta1.AdoptedNamespaceAnnotation] = tenant.GetName()
+		ns.SetAnnotations(annotations)
+
+		return r.Update(context.TODO(), ns)
+	})
+}
+
+// adoptionChanges lists the changes performed on a Namespace upon its adoption by the Tenant.
+func (r *TenantReconciler) adoptionChanges(tenant *capsulev1beta1.Tenant) (changes []string) {
+	changes = append(changes,
+		fmt.Sprintf("set Tenant %s as controller", tenant.GetName()),
+		fmt.Sprintf("set label capsule.clastix.io/tenant=%s", tenant.GetName()),
+		fmt.Sprintf("set annotation %s=%s", capsulev1beta1.AdoptedNamespaceAnnotation, tenant.GetName()),
+	)
+
+	if tenant.Spec.NetworkPolicies != nil {
+		for i := range tenant.Spec.NetworkPolicies.Items {
+			changes = append(changes, fmt.Sprintf("create NetworkPolicy capsule-%s-%d", tenant.GetName(), i))
+		}
+	}
+	if tenant.Spec.LimitRanges != nil {
+		for i := range tenant.Spec.LimitRanges.Items {
+			changes = append(changes, fmt.Sprintf("create LimitRange capsule-%s-%d", tenant.GetName(), i))
+		}
+	}
+	if tenant.Spec.ResourceQuota != nil {
+		for i := range tenant.Spec.ResourceQuota.Items {
+			changes = append(changes, fmt.Sprintf("create ResourceQuota capsule-%s-%d", tenant.GetName(), i))
+		}
+	}
+
+	changes = append(changes, "create RoleBinding namespace:admin", "create RoleBinding namespace-deleter")
+	for i, rb := range tenant.Spec.AdditionalRoleBindings {
+		changes = append(changes, fmt.Sprintf("create RoleBinding capsule-%s-%d-%s", tenant.GetName(), i, rb.ClusterRoleName))
+	}
+
+	return
+}
+
+// adoptionRequests enqueues the Tenant resources adopting Namespaces when a Namespace not controlled
+// by any Tenant is created or updated, since it could match an adoption selector.
+func (r *TenantReconciler) adoptionRequests(object client.Object) (requests []reconcile.Request) {
+	if metav1.GetControllerOf(object) != nil {
+		return nil
+	}
+
+	tl := &capsulev1beta1.TenantList{}
+	if err := r.List(context.TODO(), tl); err != nil {
+		r.Log.Error(err, "Cannot list Tenant resources for Namespace adoption")
+		return nil
+	}
+
+	for _, tnt := range tl.Items {
+		if tnt.Spec.NamespaceAdoption == nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tnt.GetName()}})
+	}
+
+	return
+}
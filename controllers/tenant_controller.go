@@ -9,14 +9,14 @@ import (
 	"hash/fnv"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
-	"golang.org/x/sync/errgroup"
+	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -26,12 +26,18 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 	"github.com/clastix/capsule/controllers/rbac"
+	"github.com/clastix/capsule/pkg/configuration"
 )
 
 // TenantReconciler reconciles a Tenant object
@@ -40,16 +46,27 @@ type TenantReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// Maximum number of Tenant resources reconciled concurrently.
+	MaxConcurrentReconciles int
+	// Maximum number of Namespaces of a single Tenant synchronized concurrently.
+	NamespaceWorkers int
+	// Capsule configuration, the protected Namespaces are never adopted.
+	Configuration configuration.Configuration
+	// Namespace Capsule is running in, never adopted.
+	CapsuleNamespace string
 }
 
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capsulev1beta1.Tenant{}).
+		// Status updates are ignored, such as the quota reservations performed by the webhook
+		For(&capsulev1beta1.Tenant{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Owns(&corev1.Namespace{}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(r.adoptionRequests)).
 		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.LimitRange{}).
 		Owns(&corev1.ResourceQuota{}).
 		Owns(&rbacv1.RoleBinding{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
@@ -67,12 +84,43 @@ func (r TenantReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		r.Log.Error(err, "Error reading the object")
 		return
 	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		r.Log.Info("Finalizing the Tenant", "deletionPolicy", instance.GetDeletionPolicy())
+		if err = r.finalizeTenant(instance); err != nil {
+			r.Log.Error(err, "Cannot finalize the Tenant")
+		}
+		return
+	}
+
+	r.Log.Info("Ensuring the Tenant deletion finalizer")
+	if err = r.ensureDeletionFinalizer(instance); err != nil {
+		r.Log.Error(err, "Cannot update the Tenant finalizers")
+		return
+	}
+
 	// Ensuring the Tenant Status
 	if err = r.updateTenantStatus(instance); err != nil {
 		r.Log.Error(err, "Cannot update Tenant status")
 		return
 	}
 
+	report := &tenantSyncReport{}
+
+	if instance.Spec.NamespaceAdoption != nil && !r.Configuration.HasSynced() {
+		// the protected Namespaces are unknown until the CapsuleConfiguration is loaded
+		r.Log.Info("Waiting for the CapsuleConfiguration before adopting Namespaces")
+		return reconcile.Result{RequeueAfter: time.Second}, nil
+	}
+
+	if instance.Spec.NamespaceAdoption != nil {
+		r.Log.Info("Starting adoption of Namespaces")
+		if report.adoption, err = r.adoptNamespaces(instance); err != nil {
+			r.Log.Error(err, "Cannot adopt Namespaces")
+		}
+		report.record(capsulev1beta1.NamespacesAdoptedCondition, err)
+	}
+
 	// Ensuring all namespaces are collected
 	r.Log.Info("Ensuring all Namespaces are collected")
 	if err = r.collectNamespaces(instance); err != nil {
@@ -83,44 +131,47 @@ func (r TenantReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 	r.Log.Info("Starting processing of Namespaces", "items", len(instance.Status.Namespaces))
 	if err = r.syncNamespaces(instance); err != nil {
 		r.Log.Error(err, "Cannot sync Namespace items")
-		return
 	}
+	report.record(capsulev1beta1.NamespacesSyncedCondition, err)
 
 	if instance.Spec.NetworkPolicies != nil {
 		r.Log.Info("Starting processing of Network Policies", "items", len(instance.Spec.NetworkPolicies.Items))
 		if err = r.syncNetworkPolicies(instance); err != nil {
 			r.Log.Error(err, "Cannot sync NetworkPolicy items")
-			return
 		}
+		report.record(capsulev1beta1.NetworkPoliciesSyncedCondition, err)
 	}
 
 	if instance.Spec.LimitRanges != nil {
 		r.Log.Info("Starting processing of Limit Ranges", "items", len(instance.Spec.LimitRanges.Items))
 		if err = r.syncLimitRanges(instance); err != nil {
 			r.Log.Error(err, "Cannot sync LimitRange items")
-			return
 		}
+		report.record(capsulev1beta1.LimitRangesSyncedCondition, err)
 	}
 
 	if instance.Spec.ResourceQuota != nil {
 		r.Log.Info("Starting processing of Resource Quotas", "items", len(instance.Spec.ResourceQuota.Items))
-		if err = r.syncResourceQuotas(instance); err != nil {
+		if report.resourceQuotas, err = r.syncResourceQuotas(instance); err != nil {
 			r.Log.Error(err, "Cannot sync ResourceQuota items")
-			return
 		}
+		report.record(capsulev1beta1.QuotaSyncedCondition, err)
 	}
 
+	var rbacErr error
+
 	r.Log.Info("Ensuring additional RoleBindings for owner")
 	if err = r.syncAdditionalRoleBindings(instance); err != nil {
 		r.Log.Error(err, "Cannot sync additional RoleBindings items")
-		return
+		rbacErr = multierror.Append(rbacErr, err)
 	}
 
 	r.Log.Info("Ensuring RoleBinding for owner")
 	if err = r.ownerRoleBinding(instance); err != nil {
 		r.Log.Error(err, "Cannot sync owner RoleBinding")
-		return
+		rbacErr = multierror.Append(rbacErr, err)
 	}
+	report.record(capsulev1beta1.RBACSyncedCondition, rbacErr)
 
 	r.Log.Info("Ensuring Namespace count")
 	if err = r.ensureNamespaceCount(instance); err != nil {
@@ -128,8 +179,47 @@ func (r TenantReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		return
 	}
 
+	r.Log.Info("Ensuring Tenant conditions")
+	// the recent quota reservations are reconciled again, once reflected in the ResourceQuota usage
+	if result.RequeueAfter, err = r.updateTenantSyncStatus(instance, report); err != nil {
+		r.Log.Error(err, "Cannot update Tenant conditions")
+		return
+	}
+
+	// Returning the aggregated errors, the Tenant is going to be requeued with the controller back-off
+	// and only the failed resources will be updated since the CreateOrUpdate is idempotent.
+	if err = report.err; err != nil {
+		r.Log.Error(err, "Tenant reconciling completed with errors")
+		return
+	}
+
 	r.Log.Info("Tenant reconciling completed")
-	return ctrl.Result{}, err
+	return result, err
+}
+
+// forEachNamespace runs the provided function for each Namespace of the Tenant, using at most NamespaceWorkers
+// concurrent workers: a failing Namespace doesn't stop the others, and all the returned errors are aggregated.
+func (r *TenantReconciler) forEachNamespace(tenant *capsulev1beta1.Tenant, fn func(namespace string) error) error {
+	workers := r.NamespaceWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	group := multierror.Group{}
+	semaphore := make(chan struct{}, workers)
+
+	for _, item := range tenant.Status.Namespaces {
+		namespace := item
+
+		semaphore <- struct{}{}
+		group.Go(func() error {
+			defer func() { <-semaphore }()
+
+			return fn(namespace)
+		})
+	}
+
+	return group.Wait().ErrorOrNil()
 }
 
 // pruningResources is taking care of removing the no more requested sub-resources as LimitRange, ResourceQuota or
@@ -169,48 +259,64 @@ func (r *TenantReconciler) pruningResources(ns string, keys []string, obj client
 	})
 }
 
-// Serial ResourceQuota processing is expensive: using Go routines we can speed it up.
-// In case of multiple errors these are logged properly, returning a generic error since we have to repush back the
-// reconciliation loop.
-func (r *TenantReconciler) resourceQuotasUpdate(resourceName corev1.ResourceName, actual, limit resource.Quantity, list ...corev1.ResourceQuota) error {
-	g := errgroup.Group{}
-
-	for _, item := range list {
-		rq := item
-		g.Go(func() error {
-			found := &corev1.ResourceQuota{}
-			if err := r.Get(context.TODO(), types.NamespacedName{Namespace: rq.Namespace, Name: rq.Name}, found); err != nil {
-				return err
-			}
+// pruningTransferredResources removes the sub-resources replicated for a different Tenant, since the Namespace
+// has been transferred: they're going to be replaced by the ones of the current Tenant.
+// Only the Namespaces marked by the transfer webhook are pruned, removing the marker afterwards.
+func (r *TenantReconciler) pruningTransferredResources(ns string, tenant *capsulev1beta1.Tenant) error {
+	namespace := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: ns}, namespace); err != nil {
+		return err
+	}
+	if _, ok := namespace.GetAnnotations()[capsulev1beta1.TransferredNamespaceAnnotation]; !ok {
+		return nil
+	}
+	// the Namespace is not owned by the current Tenant anymore
+	if owner := metav1.GetControllerOf(namespace); owner == nil || owner.UID != tenant.GetUID() {
+		return nil
+	}
 
-			return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-				_, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, found, func() error {
-					// Ensuring annotation map is there to avoid uninitialized map error and
-					// assigning the overall usage
-					if found.Annotations == nil {
-						found.Annotations = make(map[string]string)
-					}
-					found.Labels = rq.Labels
-					found.Annotations[capsulev1beta1.UsedQuotaFor(resourceName)] = actual.String()
-					found.Annotations[capsulev1beta1.HardQuotaFor(resourceName)] = limit.String()
-					// Updating the Resource according to the actual.Cmp result
-					found.Spec.Hard = rq.Spec.Hard
-					return nil
-				})
-				return err
+	tl, err := capsulev1beta1.GetTypeLabel(&capsulev1beta1.Tenant{})
+	if err != nil {
+		return err
+	}
+
+	exists, err := labels.NewRequirement(tl, selection.Exists, []string{})
+	if err != nil {
+		return err
+	}
+	notIn, err := labels.NewRequirement(tl, selection.NotIn, []string{tenant.GetName()})
+	if err != nil {
+		return err
+	}
+	s := labels.NewSelector().Add(*exists, *notIn)
+
+	for _, obj := range []client.Object{&networkingv1.NetworkPolicy{}, &corev1.LimitRange{}, &corev1.ResourceQuota{}, &rbacv1.RoleBinding{}} {
+		err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			return r.DeleteAllOf(context.TODO(), obj, &client.DeleteAllOfOptions{
+				ListOptions: client.ListOptions{
+					LabelSelector: s,
+					Namespace:     ns,
+				},
+				DeleteOptions: client.DeleteOptions{},
 			})
 		})
+		if err != nil {
+			return err
+		}
 	}
 
-	var err error
-	if err = g.Wait(); err != nil {
-		// We had an error and we mark the whole transaction as failed
-		// to process it another time according to the Tenant controller back-off factor.
-		r.Log.Error(err, "Cannot update outer ResourceQuotas", "resourceName", resourceName.String())
-		err = fmt.Errorf("update of outer ResourceQuota items has failed: %s", err.Error())
-	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: ns}, namespace); err != nil {
+			return err
+		}
+		if _, ok := namespace.GetAnnotations()[capsulev1beta1.TransferredNamespaceAnnotation]; !ok {
+			return nil
+		}
 
-	return err
+		delete(namespace.Annotations, capsulev1beta1.TransferredNamespaceAnnotation)
+
+		return r.Update(context.TODO(), namespace)
+	})
 }
 
 // Additional Role Bindings can be used in many ways: applying Pod Security Policies or giving
@@ -244,10 +350,13 @@ func (r *TenantReconciler) syncAdditionalRoleBindings(tenant *capsulev1beta1.Ten
 		return
 	}
 
-	for _, ns := range tenant.Status.Namespaces {
-		if err = r.pruningResources(ns, keys, &rbacv1.RoleBinding{}); err != nil {
-			return err
+	return r.forEachNamespace(tenant, func(ns string) error {
+		if err := r.pruningResources(ns, keys, &rbacv1.RoleBinding{}); err != nil {
+			return newNamespaceError(ns, "RoleBinding", err)
 		}
+
+		var errs *multierror.Error
+
 		for i, roleBinding := range tenant.Spec.AdditionalRoleBindings {
 			lv := hash(roleBinding)
 			rb := &rbacv1.RoleBinding{
@@ -256,8 +365,7 @@ func (r *TenantReconciler) syncAdditionalRoleBindings(tenant *capsulev1beta1.Ten
 					Namespace: ns,
 				},
 			}
-			var res controllerutil.OperationResult
-			res, err = controllerutil.CreateOrUpdate(context.TODO(), r.Client, rb, func() error {
+			res, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, rb, func() error {
 				rb.ObjectMeta.Labels = map[string]string{
 					tl: tenant.Name,
 					ll: lv,
@@ -267,7 +375,7 @@ func (r *TenantReconciler) syncAdditionalRoleBindings(tenant *capsulev1beta1.Ten
 					Kind:     "ClusterRole",
 					Name:     roleBinding.ClusterRoleName,
 				}
-				rb.Subjects = roleBinding.Subjects
+				rb.Subjects = append([]rbacv1.Subject(nil), roleBinding.Subjects...)
 
 				return controllerutil.SetControllerReference(tenant, rb, r.Scheme)
 			})
@@ -279,142 +387,12 @@ func (r *TenantReconciler) syncAdditionalRoleBindings(tenant *capsulev1beta1.Ten
 			}
 			r.Log.Info(fmt.Sprintf("Additional RoleBindings sync result: %s", string(res)), "name", rb.Name, "namespace", rb.Namespace)
 			if err != nil {
-				return
-			}
-		}
-	}
-
-	return nil
-}
-
-// We're relying on the ResourceQuota resource to represent the resource quota for the single Tenant rather than the
-// single Namespace, so abusing of this API although its Namespaced scope.
-// Since a Namespace could take-up all the available resource quota, the Namespace ResourceQuota will be a 1:1 mapping
-// to the Tenant one: in a second time Capsule is going to sum all the analogous ResourceQuota resources on other Tenant
-// namespaces to check if the Tenant quota has been exceeded or not, reusing the native Kubernetes policy putting the
-// .Status.Used value as the .Hard value.
-// This will trigger a following reconciliation but that's ok: the mutateFn will re-use the same business logic, letting
-// the mutateFn along with the CreateOrUpdate to don't perform the update since resources are identical.
-func (r *TenantReconciler) syncResourceQuotas(tenant *capsulev1beta1.Tenant) error {
-	// getting requested ResourceQuota keys
-	keys := make([]string, 0, len(tenant.Spec.ResourceQuota.Items))
-	for i := range tenant.Spec.ResourceQuota.Items {
-		keys = append(keys, strconv.Itoa(i))
-	}
-
-	// getting ResourceQuota labels for the mutateFn
-	tenantLabel, err := capsulev1beta1.GetTypeLabel(&capsulev1beta1.Tenant{})
-	if err != nil {
-		return err
-	}
-	typeLabel, err := capsulev1beta1.GetTypeLabel(&corev1.ResourceQuota{})
-	if err != nil {
-		return err
-	}
-
-	for _, ns := range tenant.Status.Namespaces {
-		if err := r.pruningResources(ns, keys, &corev1.ResourceQuota{}); err != nil {
-			return err
-		}
-		for i, q := range tenant.Spec.ResourceQuota.Items {
-			target := &corev1.ResourceQuota{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      fmt.Sprintf("capsule-%s-%d", tenant.Name, i),
-					Namespace: ns,
-				},
-			}
-			res, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, target, func() (err error) {
-				target.SetLabels(map[string]string{
-					tenantLabel: tenant.Name,
-					typeLabel:   strconv.Itoa(i),
-				})
-				// Requirement to list ResourceQuota of the current Tenant
-				tr, err := labels.NewRequirement(tenantLabel, selection.Equals, []string{tenant.Name})
-				if err != nil {
-					r.Log.Error(err, "Cannot build ResourceQuota Tenant requirement")
-				}
-				// Requirement to list ResourceQuota for the current index
-				ir, err := labels.NewRequirement(typeLabel, selection.Equals, []string{strconv.Itoa(i)})
-				if err != nil {
-					r.Log.Error(err, "Cannot build ResourceQuota index requirement")
-				}
-
-				// Listing all the ResourceQuota according to the said requirements.
-				// These are required since Capsule is going to sum all the used quota to
-				// sum them and get the Tenant one.
-				rql := &corev1.ResourceQuotaList{}
-				err = r.List(context.TODO(), rql, &client.ListOptions{
-					LabelSelector: labels.NewSelector().Add(*tr).Add(*ir),
-				})
-				if err != nil {
-					r.Log.Error(err, "Cannot list ResourceQuota", "tenantFilter", tr.String(), "indexFilter", ir.String())
-					return err
-				}
-
-				// Iterating over all the options declared for the ResourceQuota,
-				// summing all the used quota across different Namespaces to determinate
-				// if we're hitting a Hard quota at Tenant level.
-				// For this case, we're going to block the Quota setting the Hard as the
-				// used one.
-				for rn, rq := range q.Hard {
-					r.Log.Info("Desired hard " + rn.String() + " quota is " + rq.String())
-
-					// Getting the whole usage across all the Tenant Namespaces
-					var qt resource.Quantity
-					for _, rq := range rql.Items {
-						qt.Add(rq.Status.Used[rn])
-					}
-					r.Log.Info("Computed " + rn.String() + " quota for the whole Tenant is " + qt.String())
-
-					switch qt.Cmp(q.Hard[rn]) {
-					case 0:
-						// The Tenant is matching exactly the Quota:
-						// falling through next case since we have to block further
-						// resource allocations.
-						fallthrough
-					case 1:
-						// The Tenant is OverQuota:
-						// updating all the related ResourceQuota with the current
-						// used Quota to block further creations.
-						for i := range rql.Items {
-							if _, ok := rql.Items[i].Status.Used[rn]; ok {
-								rql.Items[i].Spec.Hard[rn] = rql.Items[i].Status.Used[rn]
-							} else {
-								um := make(map[corev1.ResourceName]resource.Quantity)
-								um[rn] = resource.Quantity{}
-								rql.Items[i].Spec.Hard = um
-							}
-						}
-					default:
-						// The Tenant is respecting the Hard quota:
-						// restoring the default one for all the elements,
-						// also for the reconciled one.
-						for i := range rql.Items {
-							if rql.Items[i].Spec.Hard == nil {
-								rql.Items[i].Spec.Hard = map[corev1.ResourceName]resource.Quantity{}
-							}
-							rql.Items[i].Spec.Hard[rn] = q.Hard[rn]
-						}
-						target.Spec = q
-					}
-					if err := r.resourceQuotasUpdate(rn, qt, q.Hard[rn], rql.Items...); err != nil {
-						r.Log.Error(err, "cannot proceed with outer ResourceQuota")
-						return err
-					}
-				}
-				return controllerutil.SetControllerReference(tenant, target, r.Scheme)
-			})
-
-			r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring ResourceQuota %s", target.GetName()), err)
-
-			r.Log.Info("Resource Quota sync result: "+string(res), "name", target.Name, "namespace", target.Namespace)
-			if err != nil {
-				return err
+				errs = multierror.Append(errs, newNamespaceError(ns, "RoleBinding", err))
 			}
 		}
-	}
 
-	return nil
+		return errs.ErrorOrNil()
+	})
 }
 
 // Ensuring all the LimitRange are applied to each Namespace handled by the Tenant.
@@ -435,10 +413,13 @@ func (r *TenantReconciler) syncLimitRanges(tenant *capsulev1beta1.Tenant) error
 		return err
 	}
 
-	for _, ns := range tenant.Status.Namespaces {
+	return r.forEachNamespace(tenant, func(ns string) error {
 		if err := r.pruningResources(ns, keys, &corev1.LimitRange{}); err != nil {
-			return err
+			return newNamespaceError(ns, "LimitRange", err)
 		}
+
+		var errs *multierror.Error
+
 		for i, spec := range tenant.Spec.LimitRanges.Items {
 			t := &corev1.LimitRange{
 				ObjectMeta: metav1.ObjectMeta{
@@ -451,7 +432,7 @@ func (r *TenantReconciler) syncLimitRanges(tenant *capsulev1beta1.Tenant) error
 					tl: tenant.Name,
 					ll: strconv.Itoa(i),
 				}
-				t.Spec = spec
+				t.Spec = *spec.DeepCopy()
 				return controllerutil.SetControllerReference(tenant, t, r.Scheme)
 			})
 
@@ -459,12 +440,12 @@ func (r *TenantReconciler) syncLimitRanges(tenant *capsulev1beta1.Tenant) error
 
 			r.Log.Info("LimitRange sync result: "+string(res), "name", t.Name, "namespace", t.Namespace)
 			if err != nil {
-				return err
+				errs = multierror.Append(errs, newNamespaceError(ns, "LimitRange", err))
 			}
 		}
-	}
 
-	return nil
+		return errs.ErrorOrNil()
+	})
 }
 
 func (r *TenantReconciler) syncNamespaceMetadata(namespace string, tnt *capsulev1beta1.Tenant) (err error) {
@@ -472,7 +453,12 @@ func (r *TenantReconciler) syncNamespaceMetadata(namespace string, tnt *capsulev
 
 	err = retry.RetryOnConflict(retry.DefaultBackoff, func() (conflictErr error) {
 		ns := &corev1.Namespace{}
-		if conflictErr = r.Client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if conflictErr = r.Client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); conflictErr != nil {
+			return
+		}
+		// The Namespace has been transferred to a different Tenant in the meanwhile:
+		// the Tenant label must not be restored, since it would trigger a new transfer.
+		if owner := metav1.GetControllerOf(ns); owner != nil && owner.UID != tnt.GetUID() {
 			return
 		}
 
@@ -520,6 +506,13 @@ func (r *TenantReconciler) syncNamespaceMetadata(namespace string, tnt *capsulev
 				}
 			}
 
+			// the adoption and transfer markers are managed apart from the Tenant metadata
+			for _, key := range []string{capsulev1beta1.AdoptedNamespaceAnnotation, capsulev1beta1.TransferredNamespaceAnnotation} {
+				if value, ok := ns.GetAnnotations()[key]; ok {
+					a[key] = value
+				}
+			}
+
 			ns.SetAnnotations(a)
 
 			l := make(map[string]string)
@@ -548,18 +541,19 @@ func (r *TenantReconciler) syncNamespaceMetadata(namespace string, tnt *capsulev
 
 // Ensuring all annotations are applied to each Namespace handled by the Tenant.
 func (r *TenantReconciler) syncNamespaces(tenant *capsulev1beta1.Tenant) (err error) {
-	group := errgroup.Group{}
+	err = r.forEachNamespace(tenant, func(namespace string) error {
+		if err := r.pruningTransferredResources(namespace, tenant); err != nil {
+			return newNamespaceError(namespace, "Namespace", err)
+		}
 
-	for _, item := range tenant.Status.Namespaces {
-		namespace := item
-		group.Go(func() error {
-			return r.syncNamespaceMetadata(namespace, tenant)
-		})
-	}
+		if err := r.syncNamespaceMetadata(namespace, tenant); err != nil {
+			return newNamespaceError(namespace, "Namespace", err)
+		}
 
-	if err = group.Wait(); err != nil {
+		return nil
+	})
+	if err != nil {
 		r.Log.Error(err, "Cannot sync Namespaces")
-		err = fmt.Errorf("cannot sync Namespaces: %s", err.Error())
 	}
 	return
 }
@@ -582,10 +576,13 @@ func (r *TenantReconciler) syncNetworkPolicies(tenant *capsulev1beta1.Tenant) er
 		return err
 	}
 
-	for _, ns := range tenant.Status.Namespaces {
+	return r.forEachNamespace(tenant, func(ns string) error {
 		if err := r.pruningResources(ns, keys, &networkingv1.NetworkPolicy{}); err != nil {
-			return err
+			return newNamespaceError(ns, "NetworkPolicy", err)
 		}
+
+		var errs *multierror.Error
+
 		for i, spec := range tenant.Spec.NetworkPolicies.Items {
 			t := &networkingv1.NetworkPolicy{
 				ObjectMeta: metav1.ObjectMeta{
@@ -598,7 +595,7 @@ func (r *TenantReconciler) syncNetworkPolicies(tenant *capsulev1beta1.Tenant) er
 					tl: tenant.Name,
 					nl: strconv.Itoa(i),
 				})
-				t.Spec = spec
+				t.Spec = *spec.DeepCopy()
 
 				return controllerutil.SetControllerReference(tenant, t, r.Scheme)
 			})
@@ -607,12 +604,12 @@ func (r *TenantReconciler) syncNetworkPolicies(tenant *capsulev1beta1.Tenant) er
 
 			r.Log.Info("Network Policy sync result: "+string(res), "name", t.Name, "namespace", t.Namespace)
 			if err != nil {
-				return err
+				errs = multierror.Append(errs, newNamespaceError(ns, "NetworkPolicy", err))
 			}
 		}
-	}
 
-	return nil
+		return errs.ErrorOrNil()
+	})
 }
 
 // Each Tenant owner needs the admin Role attached to each Namespace, otherwise no actions on it can be performed.
@@ -628,15 +625,18 @@ func (r *TenantReconciler) ownerRoleBinding(tenant *capsulev1beta1.Tenant) error
 		return err
 	}
 
-	l := map[string]string{tl: tenant.Name}
-
 	for _, owner := range tenant.Spec.Owners {
 		if owner.Kind == "ServiceAccount" {
-			splitName := strings.Split(owner.Name, ":")
+			namespace, name, err := owner.ServiceAccountNamespacedName()
+			if err != nil {
+				r.Log.Error(err, "Skipping malformed ServiceAccount owner")
+
+				continue
+			}
 			subjects = append(subjects, rbacv1.Subject{
 				Kind:      owner.Kind.String(),
-				Name:      splitName[len(splitName)-1],
-				Namespace: splitName[len(splitName)-2],
+				Name:      name,
+				Namespace: namespace,
 			})
 		} else {
 			subjects = append(subjects, rbacv1.Subject{
@@ -647,44 +647,49 @@ func (r *TenantReconciler) ownerRoleBinding(tenant *capsulev1beta1.Tenant) error
 		}
 	}
 
-	rbl := make(map[types.NamespacedName]rbacv1.RoleRef)
-	for _, i := range tenant.Status.Namespaces {
-		rbl[types.NamespacedName{Namespace: i, Name: "namespace:admin"}] = rbacv1.RoleRef{
+	roleRefs := map[string]rbacv1.RoleRef{
+		"namespace:admin": {
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
 			Name:     "admin",
-		}
-		rbl[types.NamespacedName{Namespace: i, Name: "namespace-deleter"}] = rbacv1.RoleRef{
+		},
+		"namespace-deleter": {
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
 			Name:     rbac.DeleterRoleName,
-		}
+		},
 	}
 
-	for nn, rr := range rbl {
-		target := &rbacv1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      nn.Name,
-				Namespace: nn.Namespace,
-			},
-		}
+	return r.forEachNamespace(tenant, func(ns string) error {
+		var errs *multierror.Error
 
-		var res controllerutil.OperationResult
-		res, err = controllerutil.CreateOrUpdate(context.TODO(), r.Client, target, func() (err error) {
-			target.ObjectMeta.Labels = l
-			target.Subjects = subjects
-			target.RoleRef = rr
-			return controllerutil.SetControllerReference(tenant, target, r.Scheme)
-		})
+		for name, rr := range roleRefs {
+			roleRef := rr
+			target := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns,
+				},
+			}
+
+			res, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, target, func() (err error) {
+				// the labels and the subjects are decoded from the API server response, and cannot be shared across the workers
+				target.ObjectMeta.Labels = map[string]string{tl: tenant.Name}
+				target.Subjects = append([]rbacv1.Subject(nil), subjects...)
+				target.RoleRef = roleRef
+				return controllerutil.SetControllerReference(tenant, target, r.Scheme)
+			})
 
-		r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring Capsule RoleBinding %s", target.GetName()), err)
+			r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring Capsule RoleBinding %s", target.GetName()), err)
 
-		r.Log.Info("Role Binding sync result: "+string(res), "name", target.Name, "namespace", target.Namespace)
-		if err != nil {
-			return err
+			r.Log.Info("Role Binding sync result: "+string(res), "name", target.Name, "namespace", target.Namespace)
+			if err != nil {
+				errs = multierror.Append(errs, newNamespaceError(ns, "RoleBinding", err))
+			}
 		}
-	}
-	return nil
+
+		return errs.ErrorOrNil()
+	})
 }
 
 func (r *TenantReconciler) ensureNamespaceCount(tenant *capsulev1beta1.Tenant) error {
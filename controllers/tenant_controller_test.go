@@ -0,0 +1,84 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// decodingClient decodes the stored object into the written one, as the API server response does.
+type decodingClient struct {
+	client.Client
+}
+
+func (c decodingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	return c.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+}
+
+func (c decodingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	return c.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+}
+
+func TestOwnerRoleBinding(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, capsulev1beta1.AddToScheme(scheme))
+
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "solar", UID: "solar"},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{Kind: "User", Name: "alice"},
+				{Kind: "ServiceAccount", Name: "system:serviceaccount:solar-ci:deployer"},
+			},
+		},
+	}
+	for i := 0; i < 16; i++ {
+		tnt.Status.Namespaces = append(tnt.Status.Namespaces, fmt.Sprintf("solar-%d", i))
+	}
+
+	r := &TenantReconciler{
+		Client:           decodingClient{Client: fake.NewClientBuilder().WithScheme(scheme).Build()},
+		Log:              log.Log,
+		Scheme:           scheme,
+		Recorder:         record.NewFakeRecorder(128),
+		NamespaceWorkers: 8,
+	}
+	// the second run updates the existing RoleBindings
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, r.ownerRoleBinding(tnt))
+	}
+
+	for _, ns := range tnt.Status.Namespaces {
+		rb := &rbacv1.RoleBinding{}
+		assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKey{Namespace: ns, Name: "namespace:admin"}, rb))
+		assert.Equal(t, map[string]string{"capsule.clastix.io/tenant": tnt.GetName()}, rb.GetLabels())
+		assert.Equal(t, []rbacv1.Subject{
+			{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: "alice"},
+			{Kind: "ServiceAccount", Name: "deployer", Namespace: "solar-ci"},
+		}, rb.Subjects)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// ensureDeletionFinalizer puts the finalizer on the Tenant resources with the Orphan deletion policy, as well as
+// on the ones with the Cascade deletion policy adopting Namespaces, removing it when no more required.
+func (r *TenantReconciler) ensureDeletionFinalizer(tenant *capsulev1beta1.Tenant) error {
+	orphan, err := r.orphansNamespaces(tenant)
+	if err != nil {
+		return err
+	}
+	if orphan == controllerutil.ContainsFinalizer(tenant, capsulev1beta1.OrphanNamespacesFinalizer) {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if err = r.Get(context.TODO(), types.NamespacedName{Name: tenant.GetName()}, tenant); err != nil {
+			return
+		}
+
+		if orphan {
+			controllerutil.AddFinalizer(tenant, capsulev1beta1.OrphanNamespacesFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(tenant, capsulev1beta1.OrphanNamespacesFinalizer)
+		}
+
+		return r.Update(context.TODO(), tenant)
+	})
+}
+
+// orphansNamespaces returns true if some Namespaces must be detached upon the Tenant deletion: all of them
+// with the Orphan deletion policy, the adopted ones with the Cascade one, since they were created outside of Capsule.
+func (r *TenantReconciler) orphansNamespaces(tenant *capsulev1beta1.Tenant) (bool, error) {
+	switch tenant.GetDeletionPolicy() {
+	case capsulev1beta1.DeletionPolicyOrphan:
+		return true, nil
+	case capsulev1beta1.DeletionPolicyCascade:
+		// the finalizer is put prior to the adoption, the Namespaces could be deleted in the meanwhile otherwise
+		if tenant.Spec.NamespaceAdoption != nil {
+			return true, nil
+		}
+
+		namespaces, err := r.tenantNamespaces(tenant, true)
+
+		return len(namespaces) > 0, err
+	default:
+		return false, nil
+	}
+}
+
+// tenantNamespaces returns the Namespaces owned by the Tenant, only the adopted ones if requested.
+func (r *TenantReconciler) tenantNamespaces(tenant *capsulev1beta1.Tenant, adopted bool) ([]corev1.Namespace, error) {
+	nl := &corev1.NamespaceList{}
+	if err := r.List(context.TODO(), nl, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".metadata.ownerReferences[*].capsule", tenant.GetName()),
+	}); err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]corev1.Namespace, 0, len(nl.Items))
+	for _, ns := range nl.Items {
+		if _, ok := ns.GetAnnotations()[capsulev1beta1.AdoptedNamespaceAnnotation]; adopted && !ok {
+			continue
+		}
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces, nil
+}
+
+// finalizeTenant detaches the Namespaces of a Tenant with the Orphan deletion policy, or the adopted ones with the
+// Cascade one: removing the Tenant owner reference, the garbage collector keeps the Namespaces along with their
+// workloads, while the resources replicated by Capsule, owned by the Tenant, are deleted. The Tenant label and the
+// adoption annotation are removed as well, the Namespaces are no more selected as Tenant ones.
+func (r *TenantReconciler) finalizeTenant(tenant *capsulev1beta1.Tenant) error {
+	if !controllerutil.ContainsFinalizer(tenant, capsulev1beta1.OrphanNamespacesFinalizer) {
+		return nil
+	}
+
+	switch tenant.GetDeletionPolicy() {
+	case capsulev1beta1.DeletionPolicyOrphan:
+		if err := r.orphanNamespaces(tenant, false); err != nil {
+			return err
+		}
+	case capsulev1beta1.DeletionPolicyCascade:
+		if err := r.orphanNamespaces(tenant, true); err != nil {
+			return err
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if err = r.Get(context.TODO(), types.NamespacedName{Name: tenant.GetName()}, tenant); err != nil {
+			return
+		}
+
+		controllerutil.RemoveFinalizer(tenant, capsulev1beta1.OrphanNamespacesFinalizer)
+
+		return r.Update(context.TODO(), tenant)
+	})
+}
+
+func (r *TenantReconciler) orphanNamespaces(tenant *capsulev1beta1.Tenant, adopted bool) error {
+	namespaces, err := r.tenantNamespaces(tenant, adopted)
+	if err != nil {
+		return err
+	}
+
+	tl, err := capsulev1beta1.GetTypeLabel(&capsulev1beta1.Tenant{})
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+
+	for _, item := range namespaces {
+		ns := item.DeepCopy()
+
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+			if err = r.Get(context.TODO(), types.NamespacedName{Name: ns.GetName()}, ns); err != nil {
+				return
+			}
+
+			refs := make([]metav1.OwnerReference, 0, len(ns.GetOwnerReferences()))
+			for _, ref := range ns.GetOwnerReferences() {
+				if ref.UID != tenant.GetUID() {
+					refs = append(refs, ref)
+				}
+			}
+			ns.SetOwnerReferences(refs)
+
+			delete(ns.Labels, tl)
+			delete(ns.Annotations, capsulev1beta1.AdoptedNamespaceAnnotation)
+
+			return r.Update(context.TODO(), ns)
+		})
+		if err != nil {
+			r.Log.Error(err, "Cannot orphan Namespace", "namespace", ns.GetName())
+			errs = multierror.Append(errs, newNamespaceError(ns.GetName(), "Namespace", err))
+
+			continue
+		}
+
+		r.Log.Info("Namespace has been orphaned", "namespace", ns.GetName())
+		r.Recorder.Eventf(tenant, corev1.EventTypeNormal, "NamespaceOrphaned", "Namespace %s has been detached from the Tenant", ns.GetName())
+	}
+
+	return errs.ErrorOrNil()
+}
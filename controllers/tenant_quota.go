@@ -0,0 +1,164 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/quota"
+)
+
+// quotaAnnotationPrefix is shared by the used and hard annotations of the Tenant scoped items.
+const quotaAnnotationPrefix = "quota.capsule.clastix.io/"
+
+// Capsule enforces the Tenant scoped ResourceQuota items at Tenant level: each item is replicated in all the Tenant
+// Namespaces, and the usage across them is aggregated in the Tenant status.
+// The resources whose usage is computed upon admission (the ones of Pods, PersistentVolumeClaims, and Services) are
+// reserved atomically in the Tenant status by the quota webhook, while for the remaining ones Capsule relies on the
+// native Kubernetes policy, putting the .Status.Used value as the .Hard one to block further allocations once the
+// Tenant quota has been reached.
+// The quota.capsule.clastix.io/used-* and hard-* annotations are still applied for compatibility.
+// The Namespace scoped items are copied verbatim in each Namespace, like the LimitRange ones.
+func (r *TenantReconciler) syncResourceQuotas(tenant *capsulev1beta1.Tenant) ([]capsulev1beta1.TenantResourceQuotaStatus, error) {
+	// getting requested ResourceQuota keys
+	keys := make([]string, 0, len(tenant.Spec.ResourceQuota.Items))
+	for i := range tenant.Spec.ResourceQuota.Items {
+		keys = append(keys, strconv.Itoa(i))
+	}
+
+	// getting ResourceQuota labels for the mutateFn
+	tenantLabel, err := capsulev1beta1.GetTypeLabel(&capsulev1beta1.Tenant{})
+	if err != nil {
+		return nil, err
+	}
+	typeLabel, err := capsulev1beta1.GetTypeLabel(&corev1.ResourceQuota{})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]capsulev1beta1.TenantResourceQuotaStatus, 0, len(tenant.Spec.ResourceQuota.Items))
+	for i, q := range tenant.Spec.ResourceQuota.Items {
+		if !q.IsTenantScoped() {
+			continue
+		}
+
+		var used corev1.ResourceList
+		if used, err = r.resourceQuotaUsage(tenant, tenantLabel, typeLabel, i, q.ResourceQuotaSpec); err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, capsulev1beta1.TenantResourceQuotaStatus{
+			Index: i,
+			Hard:  q.Hard,
+			Used:  used,
+		})
+	}
+
+	err = r.forEachNamespace(tenant, func(ns string) error {
+		if err := r.pruningResources(ns, keys, &corev1.ResourceQuota{}); err != nil {
+			return newNamespaceError(ns, "ResourceQuota", err)
+		}
+
+		var errs *multierror.Error
+
+		for i, q := range tenant.Spec.ResourceQuota.Items {
+			index, item := i, q
+
+			target := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("capsule-%s-%d", tenant.Name, index),
+					Namespace: ns,
+				},
+			}
+			res, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, target, func() (err error) {
+				target.SetLabels(map[string]string{
+					tenantLabel: tenant.Name,
+					typeLabel:   strconv.Itoa(index),
+				})
+				if target.Annotations == nil {
+					target.Annotations = make(map[string]string)
+				}
+
+				target.Spec = *item.ResourceQuotaSpec.DeepCopy()
+
+				if !item.IsTenantScoped() {
+					for k := range target.Annotations {
+						if strings.HasPrefix(k, quotaAnnotationPrefix) {
+							delete(target.Annotations, k)
+						}
+					}
+
+					return controllerutil.SetControllerReference(tenant, target, r.Scheme)
+				}
+
+				var used corev1.ResourceList
+				for _, status := range statuses {
+					if status.Index == index {
+						used = status.Used
+					}
+				}
+
+				for name, hard := range item.Hard {
+					tenantUsed := used[name]
+
+					target.Annotations[capsulev1beta1.UsedQuotaFor(name)] = tenantUsed.String()
+					target.Annotations[capsulev1beta1.HardQuotaFor(name)] = hard.String()
+
+					if quota.IsTracked(name) || tenantUsed.Cmp(hard) < 0 {
+						continue
+					}
+					// The Tenant is over quota for a resource not reserved upon admission:
+					// blocking further allocations using the Namespace usage as hard limit.
+					target.Spec.Hard[name] = target.Status.Used[name]
+				}
+
+				return controllerutil.SetControllerReference(tenant, target, r.Scheme)
+			})
+
+			r.emitEvent(tenant, target.GetNamespace(), res, fmt.Sprintf("Ensuring ResourceQuota %s", target.GetName()), err)
+
+			r.Log.Info("Resource Quota sync result: "+string(res), "name", target.Name, "namespace", target.Namespace)
+			if err != nil {
+				errs = multierror.Append(errs, newNamespaceError(ns, "ResourceQuota", err))
+			}
+		}
+
+		return errs.ErrorOrNil()
+	})
+
+	return statuses, err
+}
+
+// resourceQuotaUsage sums the usage of all the ResourceQuota objects of the Tenant for the given item.
+func (r *TenantReconciler) resourceQuotaUsage(tenant *capsulev1beta1.Tenant, tenantLabel, typeLabel string, index int, spec corev1.ResourceQuotaSpec) (corev1.ResourceList, error) {
+	rql := &corev1.ResourceQuotaList{}
+	if err := r.List(context.TODO(), rql, client.MatchingLabels{tenantLabel: tenant.Name, typeLabel: strconv.Itoa(index)}); err != nil {
+		r.Log.Error(err, "Cannot list ResourceQuota", "index", index)
+		return nil, err
+	}
+
+	used := corev1.ResourceList{}
+	for name := range spec.Hard {
+		var qt resource.Quantity
+		for _, rq := range rql.Items {
+			qt.Add(rq.Status.Used[name])
+		}
+		used[name] = qt
+
+		r.Log.Info("Computed "+name.String()+" quota for the whole Tenant is "+qt.String(), "index", index)
+	}
+
+	return used, nil
+}
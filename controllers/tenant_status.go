@@ -0,0 +1,197 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/quota"
+)
+
+// namespaceError is returned when a Tenant resource cannot be synchronized in one of its Namespaces.
+type namespaceError struct {
+	namespace string
+	kind      string
+	err       error
+}
+
+func newNamespaceError(namespace, kind string, err error) error {
+	return &namespaceError{namespace: namespace, kind: kind, err: err}
+}
+
+func (n namespaceError) Error() string {
+	return fmt.Sprintf("cannot sync %s in Namespace %s: %s", n.kind, n.namespace, n.err.Error())
+}
+
+func (n namespaceError) Unwrap() error {
+	return n.err
+}
+
+// tenantSyncConditions are the condition types reporting the synchronization of each Tenant resource kind.
+var tenantSyncConditions = []string{
+	capsulev1beta1.NamespacesSyncedCondition,
+	capsulev1beta1.NetworkPoliciesSyncedCondition,
+	capsulev1beta1.LimitRangesSyncedCondition,
+	capsulev1beta1.QuotaSyncedCondition,
+	capsulev1beta1.RBACSyncedCondition,
+	capsulev1beta1.NamespacesAdoptedCondition,
+}
+
+// tenantSyncReport collects the outcome of the synchronization of each Tenant resource kind,
+// in order to reflect it in the Tenant status conditions.
+type tenantSyncReport struct {
+	conditions     []metav1.Condition
+	failures       []capsulev1beta1.NamespaceFailure
+	resourceQuotas []capsulev1beta1.TenantResourceQuotaStatus
+	adoption       []capsulev1beta1.NamespaceAdoptionReport
+	err            error
+}
+
+func (s *tenantSyncReport) record(conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  capsulev1beta1.SyncedReason,
+		Message: "All resources have been synchronized",
+	}
+
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = capsulev1beta1.SyncFailedReason
+		condition.Message = err.Error()
+
+		s.failures = append(s.failures, namespaceFailures(err)...)
+		s.err = multierror.Append(s.err, err)
+	}
+
+	s.conditions = append(s.conditions, condition)
+}
+
+// ready returns the Ready condition, summarizing the failed synchronizations.
+func (s *tenantSyncReport) ready() metav1.Condition {
+	var failed []string
+	for _, condition := range s.conditions {
+		if condition.Status != metav1.ConditionTrue {
+			failed = append(failed, condition.Type)
+		}
+	}
+
+	if len(failed) > 0 {
+		return metav1.Condition{
+			Type:    capsulev1beta1.ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  capsulev1beta1.SyncFailedReason,
+			Message: fmt.Sprintf("The following conditions are not satisfied: %s", strings.Join(failed, ", ")),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    capsulev1beta1.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  capsulev1beta1.SyncedReason,
+		Message: "The Tenant has been reconciled",
+	}
+}
+
+// namespaceFailures extracts the per-Namespace failures from the errors returned by the sync functions.
+func namespaceFailures(err error) (failures []capsulev1beta1.NamespaceFailure) {
+	var errs []error
+	if merr, ok := err.(*multierror.Error); ok {
+		errs = merr.WrappedErrors()
+	} else {
+		errs = []error{err}
+	}
+
+	for _, e := range errs {
+		var nsErr *namespaceError
+		if !errors.As(e, &nsErr) {
+			continue
+		}
+
+		failures = append(failures, capsulev1beta1.NamespaceFailure{
+			Namespace: nsErr.namespace,
+			Kind:      nsErr.kind,
+			Message:   nsErr.err.Error(),
+		})
+	}
+
+	return
+}
+
+// mergeResourceQuotaStatuses merges the observed usage of the Tenant scoped ResourceQuota items with the current
+// status, keeping the recent reservations performed by the quota webhook, and returning when to reconcile again.
+func mergeResourceQuotaStatuses(current []capsulev1beta1.TenantResourceQuotaStatus, observed []capsulev1beta1.TenantResourceQuotaStatus) (statuses []capsulev1beta1.TenantResourceQuotaStatus, requeueAfter time.Duration) {
+	now := time.Now()
+
+	existing := capsulev1beta1.TenantStatus{ResourceQuotas: current}
+
+	for _, item := range observed {
+		status := *item.DeepCopy()
+
+		if reserved := existing.GetResourceQuotaStatus(item.Index); reserved != nil {
+			var remaining time.Duration
+			if status.Used, remaining = quota.MergeUsage(reserved.Used, item.Used, reserved.LastReservationTime, now); remaining > 0 {
+				status.LastReservationTime = reserved.LastReservationTime
+				if requeueAfter == 0 || remaining < requeueAfter {
+					requeueAfter = remaining
+				}
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return
+}
+
+func (r *TenantReconciler) updateTenantSyncStatus(tenant *capsulev1beta1.Tenant, report *tenantSyncReport) (requeueAfter time.Duration, err error) {
+	conditions := append(report.conditions, report.ready())
+	// Sorting the failures to keep the Tenant status stable across reconciliations
+	sort.SliceStable(report.failures, func(i, j int) bool {
+		if report.failures[i].Namespace != report.failures[j].Namespace {
+			return report.failures[i].Namespace < report.failures[j].Namespace
+		}
+
+		return report.failures[i].Kind < report.failures[j].Kind
+	})
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		found := &capsulev1beta1.Tenant{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: tenant.GetName()}, found); err != nil {
+			return err
+		}
+
+		found.Status.ObservedGeneration = tenant.GetGeneration()
+		// Removing the conditions of the resource kinds no more handled by the Tenant
+		for _, conditionType := range tenantSyncConditions {
+			if meta.FindStatusCondition(conditions, conditionType) == nil {
+				meta.RemoveStatusCondition(&found.Status.Conditions, conditionType)
+			}
+		}
+		for _, condition := range conditions {
+			condition.ObservedGeneration = tenant.GetGeneration()
+			meta.SetStatusCondition(&found.Status.Conditions, condition)
+		}
+		found.Status.FailedNamespaces = report.failures
+		// the reservations performed meanwhile by the quota webhook are retained within their grace period
+		found.Status.ResourceQuotas, requeueAfter = mergeResourceQuotaStatuses(found.Status.ResourceQuotas, report.resourceQuotas)
+		found.Status.NamespaceAdoption = report.adoption
+
+		return r.Client.Status().Update(context.TODO(), found)
+	})
+
+	return
+}
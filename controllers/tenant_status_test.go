@@ -0,0 +1,58 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestMergeResourceQuotaStatuses(t *testing.T) {
+	pods := func(used string, lastReservation *metav1.Time) capsulev1beta1.TenantResourceQuotaStatus {
+		return capsulev1beta1.TenantResourceQuotaStatus{
+			Index:               0,
+			Hard:                corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+			Used:                corev1.ResourceList{corev1.ResourcePods: resource.MustParse(used)},
+			LastReservationTime: lastReservation,
+		}
+	}
+	// the controller observed the usage of the ResourceQuota objects before the webhook reserved a further Pod
+	observed := []capsulev1beta1.TenantResourceQuotaStatus{pods("2", nil)}
+
+	t.Run("reconciling after a reservation", func(t *testing.T) {
+		reservation := metav1.NewTime(time.Now().Add(-time.Second))
+
+		statuses, requeueAfter := mergeResourceQuotaStatuses([]capsulev1beta1.TenantResourceQuotaStatus{pods("3", &reservation)}, observed)
+
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, "3", statuses[0].Used.Pods().String())
+		assert.Equal(t, &reservation, statuses[0].LastReservationTime)
+		assert.True(t, requeueAfter > 0 && requeueAfter <= time.Second*30)
+	})
+
+	t.Run("reconciling after the reservation grace period", func(t *testing.T) {
+		reservation := metav1.NewTime(time.Now().Add(-time.Minute))
+
+		statuses, requeueAfter := mergeResourceQuotaStatuses([]capsulev1beta1.TenantResourceQuotaStatus{pods("3", &reservation)}, observed)
+
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, "2", statuses[0].Used.Pods().String())
+		assert.Nil(t, statuses[0].LastReservationTime)
+		assert.Zero(t, requeueAfter)
+	})
+
+	t.Run("reconciling a new item", func(t *testing.T) {
+		statuses, requeueAfter := mergeResourceQuotaStatuses(nil, observed)
+
+		assert.Equal(t, observed, statuses)
+		assert.Zero(t, requeueAfter)
+	})
+}
@@ -44,7 +44,24 @@ var _ = Describe("creating a Namespace with an additional Role Binding", func()
 		},
 	}
 
+	cr := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "crds-rolebinding",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"apiextensions.k8s.io"},
+				Resources: []string{"customresourcedefinitions"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+
 	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			cr.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), cr)
+		}).Should(Succeed())
 		EventuallyCreation(func() error {
 			tnt.ResourceVersion = ""
 			return k8sClient.Create(context.TODO(), tnt)
@@ -52,6 +69,7 @@ var _ = Describe("creating a Namespace with an additional Role Binding", func()
 	})
 	JustAfterEach(func() {
 		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), cr)).Should(Succeed())
 	})
 
 	It("should be assigned to each Namespace", func() {
@@ -11,8 +11,11 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
@@ -34,6 +37,7 @@ var _ = Describe("enforcing an allowed set of Service external IPs", func() {
 					Allowed: []capsulev1beta1.AllowedIP{
 						"10.20.0.0/16",
 						"192.168.1.2/32",
+						"fd00:10::/64",
 					},
 				},
 			},
@@ -147,4 +151,184 @@ var _ = Describe("enforcing an allowed set of Service external IPs", func() {
 			return err
 		}).Should(Succeed())
 	})
+
+	It("should allow an IPv6 CIDR block", func() {
+		ns := NewNamespace("allowed-service-ipv6")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "dns-server",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "dns",
+						Protocol:   "UDP",
+						Port:       53,
+						TargetPort: intstr.FromInt(9053),
+					},
+				},
+				Selector: map[string]string{
+					"app": "dns-server",
+				},
+				ExternalIPs: []string{
+					"fd00:10::53",
+				},
+			},
+		}
+		EventuallyCreation(func() error {
+			cs := ownerClient(tnt.Spec.Owners[0])
+			_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), svc, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should fail requesting a forbidden load balancer IP", func() {
+		ns := NewNamespace("evil-load-balancer-ip")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-evil-load-balancer",
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "http",
+						Protocol:   "TCP",
+						Port:       80,
+						TargetPort: intstr.FromInt(8080),
+					},
+				},
+				LoadBalancerIP: "8.8.8.8",
+			},
+		}
+		EventuallyCreation(func() error {
+			cs := ownerClient(tnt.Spec.Owners[0])
+			_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), svc, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should let the cloud controller assign a load balancer ingress IP not allowed to the owner", func() {
+		ns := NewNamespace("cloud-controller-ingress-ip")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cloudController := "system:serviceaccount:kube-system:cloud-controller-manager"
+
+		role := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "e2e-service-status",
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"services/status"},
+					Verbs:     []string{"get", "update"},
+				},
+			},
+		}
+		binding := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "e2e-service-status",
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     role.GetName(),
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.UserKind,
+					Name:     cloudController,
+				},
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.UserKind,
+					Name:     tnt.Spec.Owners[0].Name,
+				},
+			},
+		}
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.TODO(), role)
+		}).Should(Succeed())
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.TODO(), binding)
+		}).Should(Succeed())
+		defer func() {
+			_ = k8sClient.Delete(context.TODO(), binding)
+			_ = k8sClient.Delete(context.TODO(), role)
+		}()
+
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "load-balancer",
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "http",
+						Protocol:   "TCP",
+						Port:       80,
+						TargetPort: intstr.FromInt(8080),
+					},
+				},
+			},
+		}
+		EventuallyCreation(func() error {
+			cs := ownerClient(tnt.Spec.Owners[0])
+			_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), svc, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+
+		updateStatus := func(cs kubernetes.Interface) error {
+			s, err := cs.CoreV1().Services(ns.Name).Get(context.Background(), svc.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			s.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "8.8.8.8"}}
+			_, err = cs.CoreV1().Services(ns.Name).UpdateStatus(context.Background(), s, metav1.UpdateOptions{})
+			return err
+		}
+
+		By("denying the owner", func() {
+			Expect(updateStatus(ownerClient(tnt.Spec.Owners[0]))).ShouldNot(Succeed())
+		})
+
+		By("allowing the cloud controller", func() {
+			c, err := config.GetConfig()
+			Expect(err).ToNot(HaveOccurred())
+			c.Impersonate.UserName = cloudController
+			c.Impersonate.Groups = []string{"system:serviceaccounts", "system:serviceaccounts:kube-system", "system:authenticated"}
+			cs, err := kubernetes.NewForConfig(c)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() error {
+				return updateStatus(cs)
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+	})
+
+	It("should fail creating a Tenant with a malformed allowed IP", func() {
+		malformed := &capsulev1beta1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "malformed-external-ip",
+			},
+			Spec: capsulev1beta1.TenantSpec{
+				Owners: tnt.Spec.Owners,
+				ServiceOptions: &capsulev1beta1.ServiceOptions{
+					ExternalServiceIPs: &capsulev1beta1.ExternalServiceIPsSpec{
+						Allowed: []capsulev1beta1.AllowedIP{"10.20.0.0/33"},
+					},
+				},
+			},
+		}
+		EventuallyCreation(func() error {
+			return k8sClient.Create(context.TODO(), malformed)
+		}).ShouldNot(Succeed())
+	})
 })
@@ -0,0 +1,113 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("enforcing a Container Image reference policy", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "container-images",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "ivy",
+					Kind: "User",
+				},
+			},
+			ContainerImages: &capsulev1beta1.ContainerImagesSpec{
+				ForbidLatestTag: true,
+			},
+		},
+	}
+
+	pod := func(name, image string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: image,
+					},
+				},
+			},
+		}
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should deny the latest and the empty tags", func() {
+		ns := NewNamespace("images-latest")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		for name, image := range map[string]string{"latest": "docker.io/nginx:latest", "empty": "docker.io/nginx"} {
+			p := pod(name, image)
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), p, metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		}
+	})
+
+	It("should allow a tagged image", func() {
+		ns := NewNamespace("images-tagged")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod("tagged", "docker.io/nginx:alpine"), metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should deny a tagged image when a digest is required", func() {
+		ns := NewNamespace("images-digest")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		Eventually(func() error {
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, tnt); err != nil {
+				return err
+			}
+			tnt.Spec.ContainerImages.RequireDigest = true
+			return k8sClient.Update(context.TODO(), tnt)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		defer func() {
+			tnt.Spec.ContainerImages.RequireDigest = false
+		}()
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod("tagged", "docker.io/nginx:alpine"), metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+})
@@ -0,0 +1,194 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("rewriting a Container Registry", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "container-registry-rewrite",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "rupert",
+					Kind: "User",
+				},
+			},
+			ContainerRegistries: &capsulev1beta1.AllowedListSpec{
+				Exact: []string{"registry.internal.tld"},
+			},
+			ContainerImages: &capsulev1beta1.ContainerImagesSpec{
+				RegistryRewrites: map[string]string{
+					"docker.io": "registry.internal.tld/dockerhub-mirror",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should rewrite the Pod images to the mirror", func() {
+		ns := NewNamespace("registry-rewrite")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:  "init",
+						Image: "busybox:1.24",
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/nginx:alpine",
+					},
+				},
+			},
+		}
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		var created *corev1.Pod
+
+		EventuallyCreation(func() (err error) {
+			created, err = cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+
+		Expect(created.Spec.InitContainers[0].Image).Should(Equal("registry.internal.tld/dockerhub-mirror/library/busybox:1.24"))
+		Expect(created.Spec.Containers[0].Image).Should(Equal("registry.internal.tld/dockerhub-mirror/library/nginx:alpine"))
+	})
+
+	It("should deny a not rewritten forbidden registry", func() {
+		ns := NewNamespace("registry-rewrite-deny")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "gcr.io/google_containers/pause-amd64:3.0",
+					},
+				},
+			},
+		}
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should rewrite the image of an updated container", func() {
+		ns := NewNamespace("registry-rewrite-update")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/nginx:alpine",
+					},
+				},
+			},
+		}
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+
+		updated, err := cs.CoreV1().Pods(ns.Name).Patch(context.Background(), pod.Name, types.StrategicMergePatchType, []byte(`{"spec":{"containers":[{"name":"container","image":"docker.io/nginx:1.21"}]}}`), metav1.PatchOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(updated.Spec.Containers[0].Image).Should(Equal("registry.internal.tld/dockerhub-mirror/library/nginx:1.21"))
+	})
+
+	It("should rewrite the image of an ephemeral container", func() {
+		maj, min, v := GetKubernetesSemVer()
+		if maj == 1 && min < 23 {
+			Skip("Running test on Kubernetes " + v + ", doesn't enable ephemeral containers by default")
+		}
+
+		ns := NewNamespace("registry-rewrite-ephemeral")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/nginx:alpine",
+					},
+				},
+			},
+		}
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+
+		patch := func(image string) error {
+			return cs.CoreV1().RESTClient().Patch(types.StrategicMergePatchType).
+				Namespace(ns.Name).
+				Resource("pods").
+				Name(pod.Name).
+				SubResource("ephemeralcontainers").
+				Body([]byte(fmt.Sprintf(`{"spec":{"ephemeralContainers":[{"name":"debugger","image":"%s","targetContainerName":"container"}]}}`, image))).
+				Do(context.Background()).
+				Error()
+		}
+
+		Expect(patch("gcr.io/google_containers/busybox:1.24")).ShouldNot(Succeed())
+		Expect(patch("docker.io/library/busybox:1.24")).Should(Succeed())
+
+		created, err := cs.CoreV1().Pods(ns.Name).Get(context.Background(), pod.Name, metav1.GetOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(created.Spec.EphemeralContainers).Should(HaveLen(1))
+		Expect(created.Spec.EphemeralContainers[0].Image).Should(Equal("registry.internal.tld/dockerhub-mirror/library/busybox:1.24"))
+	})
+})
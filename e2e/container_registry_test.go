@@ -7,6 +7,7 @@ package e2e
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -134,4 +135,77 @@ var _ = Describe("enforcing a Container Registry", func() {
 			return err
 		}).Should(Succeed())
 	})
+
+	It("should deny running a gcr.io init container", func() {
+		ns := NewNamespace("registry-init-deny")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:  "init",
+						Image: "gcr.io/google_containers/pause-amd64:3.0",
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/nginx:alpine",
+					},
+				},
+			},
+		}
+		cs := ownerClient(tnt.Spec.Owners[0])
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should deny adding a gcr.io ephemeral container", func() {
+		maj, min, v := GetKubernetesSemVer()
+		if maj == 1 && min < 23 {
+			Skip("Running test on Kubernetes " + v + ", doesn't enable ephemeral containers by default")
+		}
+
+		ns := NewNamespace("registry-ephemeral-deny")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "container",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "docker.io/nginx:alpine",
+					},
+				},
+			},
+		}
+		cs := ownerClient(tnt.Spec.Owners[0])
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+
+		patch := func(image string) error {
+			return cs.CoreV1().RESTClient().Patch(types.StrategicMergePatchType).
+				Namespace(ns.Name).
+				Resource("pods").
+				Name(pod.Name).
+				SubResource("ephemeralcontainers").
+				Body([]byte(fmt.Sprintf(`{"spec":{"ephemeralContainers":[{"name":"debugger","image":"%s","targetContainerName":"container"}]}}`, image))).
+				Do(context.Background()).
+				Error()
+		}
+
+		Expect(patch("gcr.io/google_containers/busybox:1.24")).ShouldNot(Succeed())
+		Expect(patch("docker.io/library/busybox:1.24")).Should(Succeed())
+	})
 })
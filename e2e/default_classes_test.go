@@ -0,0 +1,171 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("defaulting the Priority and Storage classes", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default-classes",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "dean",
+					Kind: "User",
+				},
+			},
+			PriorityClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"tenant-default"},
+				},
+				Default: "tenant-default",
+			},
+			StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"tenant-storage"},
+				},
+				Default: "tenant-storage",
+			},
+		},
+	}
+
+	pc := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-default",
+		},
+		Description: "fake PriorityClass for e2e",
+		Value:       1000,
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "container",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "container",
+					Image: "quay.io/google-containers/pause-amd64:3.0",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			pc.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), pc)
+		}).Should(Succeed())
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), pc)).Should(Succeed())
+	})
+
+	It("should set the default Priority Class to Pods not specifying one", func() {
+		ns := NewNamespace("default-priority-class")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		var created *corev1.Pod
+
+		EventuallyCreation(func() (err error) {
+			created, err = cs.CoreV1().Pods(ns.GetName()).Create(context.Background(), pod, metav1.CreateOptions{})
+			return
+		}).Should(Succeed())
+
+		Expect(created.Spec.PriorityClassName).Should(Equal(pc.GetName()))
+		Expect(*created.Spec.Priority).Should(Equal(pc.Value))
+	})
+
+	It("should reject Pods not specifying a required Priority Class", func() {
+		ns := NewNamespace("required-priority-class")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		Eventually(func() error {
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, tnt); err != nil {
+				return err
+			}
+			tnt.Spec.PriorityClasses.Required = true
+			return k8sClient.Update(context.TODO(), tnt)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		defer func() {
+			tnt.Spec.PriorityClasses.Required = false
+		}()
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.GetName()).Create(context.Background(), pod, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should set the default Storage Class to PersistentVolumeClaims not specifying one", func() {
+		ns := NewNamespace("default-storage-class")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "claim",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		var created *corev1.PersistentVolumeClaim
+
+		EventuallyCreation(func() (err error) {
+			created, err = cs.CoreV1().PersistentVolumeClaims(ns.GetName()).Create(context.Background(), pvc, metav1.CreateOptions{})
+			return
+		}).Should(Succeed())
+
+		Expect(created.Spec.StorageClassName).ShouldNot(BeNil())
+		Expect(*created.Spec.StorageClassName).Should(Equal("tenant-storage"))
+
+		By("leaving the explicitly empty Storage Class of statically bound PersistentVolumeClaims", func() {
+			static := pvc.DeepCopy()
+			static.SetName("static")
+			static.Spec.StorageClassName = pointer.StringPtr("")
+
+			EventuallyCreation(func() (err error) {
+				created, err = cs.CoreV1().PersistentVolumeClaims(ns.GetName()).Create(context.Background(), static, metav1.CreateOptions{})
+				return
+			}).Should(Succeed())
+
+			Expect(created.Spec.StorageClassName).ShouldNot(BeNil())
+			Expect(*created.Spec.StorageClassName).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("defaulting the ImagePullPolicy", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "image-pull-policy-default",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "dora",
+					Kind: "User",
+				},
+			},
+			ImagePullPolicies: []capsulev1beta1.ImagePullPolicySpec{"Always"},
+			ContainerImages: &capsulev1beta1.ContainerImagesSpec{
+				DefaultPullPolicy: "Always",
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should mutate the not allowed policies to the default one", func() {
+		ns := NewNamespace("default-policy")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "defaulted",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:            "if-not-present",
+						Image:           "gcr.io/google_containers/pause-amd64:3.0",
+						ImagePullPolicy: corev1.PullIfNotPresent,
+					},
+					{
+						Name:  "unset",
+						Image: "gcr.io/google_containers/pause-amd64:3.0",
+					},
+				},
+			},
+		}
+
+		var created *corev1.Pod
+
+		EventuallyCreation(func() (err error) {
+			created, err = cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+
+			return
+		}).Should(Succeed())
+
+		for _, container := range created.Spec.Containers {
+			Expect(container.ImagePullPolicy).Should(Equal(corev1.PullAlways))
+		}
+	})
+
+	It("should mutate the not allowed policy of an ephemeral container to the default one", func() {
+		maj, min, v := GetKubernetesSemVer()
+		if maj == 1 && min < 23 {
+			Skip("Running test on Kubernetes " + v + ", doesn't enable ephemeral containers by default")
+		}
+
+		ns := NewNamespace("default-policy-ephemeral")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "defaulted",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "gcr.io/google_containers/pause-amd64:3.0",
+					},
+				},
+			},
+		}
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Pods(ns.Name).Create(context.Background(), pod, metav1.CreateOptions{})
+
+			return err
+		}).Should(Succeed())
+
+		Expect(cs.CoreV1().RESTClient().Patch(types.StrategicMergePatchType).
+			Namespace(ns.Name).
+			Resource("pods").
+			Name(pod.Name).
+			SubResource("ephemeralcontainers").
+			Body([]byte(`{"spec":{"ephemeralContainers":[{"name":"debugger","image":"busybox:1.24","imagePullPolicy":"IfNotPresent","targetContainerName":"container"}]}}`)).
+			Do(context.Background()).
+			Error()).Should(Succeed())
+
+		created, err := cs.CoreV1().Pods(ns.Name).Get(context.Background(), pod.Name, metav1.GetOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(created.Spec.EphemeralContainers).Should(HaveLen(1))
+		Expect(created.Spec.EphemeralContainers[0].ImagePullPolicy).Should(Equal(corev1.PullAlways))
+	})
+})
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
@@ -30,12 +31,14 @@ var _ = Describe("when Tenant handles Ingress classes", func() {
 					Kind: "User",
 				},
 			},
-			IngressClasses: &capsulev1beta1.AllowedListSpec{
-				Exact: []string{
-					"nginx",
-					"haproxy",
+			IngressClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{
+						"nginx",
+						"haproxy",
+					},
+					Regex: "^oil-.*$",
 				},
-				Regex: "^oil-.*$",
 			},
 		},
 	}
@@ -233,4 +236,48 @@ var _ = Describe("when Tenant handles Ingress classes", func() {
 			return
 		}, 600, defaultPollInterval).Should(Succeed())
 	})
+
+	It("should set the default class to Ingresses not specifying one", func() {
+		ns := NewNamespace("ingress-class-default")
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		maj, min, v := GetKubernetesSemVer()
+		if maj == 1 && min < 18 {
+			Skip("Running test on Kubernetes " + v + ", doesn't provide .spec.ingressClassName")
+		}
+
+		Eventually(func() error {
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, tnt); err != nil {
+				return err
+			}
+			tnt.Spec.IngressClasses.Default = "nginx"
+			return k8sClient.Update(context.TODO(), tnt)
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		defer func() {
+			tnt.Spec.IngressClasses.Default = ""
+		}()
+
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		var created *extensionsv1beta1.Ingress
+
+		Eventually(func() (err error) {
+			i := &extensionsv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "defaulted",
+				},
+				Spec: extensionsv1beta1.IngressSpec{
+					Backend: &extensionsv1beta1.IngressBackend{
+						ServiceName: "foo",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			}
+			created, err = cs.ExtensionsV1beta1().Ingresses(ns.GetName()).Create(context.TODO(), i, metav1.CreateOptions{})
+			return
+		}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+		Expect(created.Spec.IngressClassName).Should(Equal(pointer.StringPtr("nginx")))
+	})
 })
@@ -14,8 +14,6 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	capsulev1alpha1 "github.com/clastix/capsule/api/v1alpha1"
-
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
 
@@ -72,7 +70,7 @@ var _ = Describe("when handling Ingress hostnames collision", func() {
 			return k8sClient.Create(context.TODO(), tnt)
 		}).Should(Succeed())
 
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.AllowIngressHostnameCollision = true
 		})
 	})
@@ -80,13 +78,13 @@ var _ = Describe("when handling Ingress hostnames collision", func() {
 	JustAfterEach(func() {
 		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
 
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.AllowIngressHostnameCollision = false
 		})
 	})
 
 	It("should not allow creating several Ingress with same hostname", func() {
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.AllowIngressHostnameCollision = false
 		})
 
@@ -0,0 +1,156 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("creating LoadBalancer services when they are constrained for Tenant", func() {
+	disabled := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "disable-load-balancers",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "lars",
+					Kind: "User",
+				},
+			},
+			ServiceOptions: &capsulev1beta1.ServiceOptions{
+				AllowedServices: &capsulev1beta1.AllowedServices{
+					LoadBalancer: pointer.BoolPtr(false),
+				},
+			},
+		},
+	}
+
+	constrained := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "constrain-load-balancers",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "lena",
+					Kind: "User",
+				},
+			},
+			ServiceOptions: &capsulev1beta1.ServiceOptions{
+				LoadBalancers: &capsulev1beta1.LoadBalancerSpec{
+					MaxCount:            pointer.Int32Ptr(1),
+					AllowedSourceRanges: []capsulev1beta1.AllowedIP{"10.0.0.0/8"},
+				},
+			},
+		},
+	}
+
+	service := func(name string, sourceRanges ...string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{
+						Port: 9999,
+						TargetPort: intstr.IntOrString{
+							Type:   intstr.Int,
+							IntVal: 9999,
+						},
+						Protocol: corev1.ProtocolTCP,
+					},
+				},
+				LoadBalancerSourceRanges: sourceRanges,
+			},
+		}
+	}
+
+	JustBeforeEach(func() {
+		for _, tnt := range []*capsulev1beta1.Tenant{disabled, constrained} {
+			EventuallyCreation(func() error {
+				tnt.ResourceVersion = ""
+				return k8sClient.Create(context.TODO(), tnt)
+			}).Should(Succeed())
+		}
+	})
+	JustAfterEach(func() {
+		for _, tnt := range []*capsulev1beta1.Tenant{disabled, constrained} {
+			Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+		}
+	})
+
+	It("should fail creating a service with LoadBalancer type", func() {
+		ns := NewNamespace("disable-load-balancers")
+		NamespaceCreation(ns, disabled.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(disabled.Spec.Owners[0])
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("disabled"), metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should enforce the source ranges and the maximum number", func() {
+		ns := NewNamespace("constrain-load-balancers")
+		NamespaceCreation(ns, constrained.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(constrained.Spec.Owners[0])
+
+		By("not specifying the source ranges", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("missing-ranges"), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("specifying a forbidden source range", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("forbidden-range", "10.1.0.0/16", "0.0.0.0/0"), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("specifying an allowed source range", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("allowed-range", "10.1.0.0/16"), metav1.CreateOptions{})
+				return err
+			}).Should(Succeed())
+		})
+		By("exceeding the maximum number", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("exceeding", "10.2.0.0/16"), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("updating an existing LoadBalancer after the policy change", func() {
+			Eventually(func() error {
+				tnt := &capsulev1beta1.Tenant{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: constrained.GetName()}, tnt); err != nil {
+					return err
+				}
+				tnt.Spec.ServiceOptions.LoadBalancers.AllowedSourceRanges = []capsulev1beta1.AllowedIP{"192.168.0.0/16"}
+				return k8sClient.Update(context.TODO(), tnt)
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+			Eventually(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Patch(context.Background(), "allowed-range", types.MergePatchType, []byte(`{"metadata":{"labels":{"updated":"true"}}}`), metav1.PatchOptions{})
+				return err
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,175 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("adopting pre-existing Namespaces", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-adoption",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "adele",
+					Kind: "User",
+				},
+			},
+			NamespaceAdoption: &capsulev1beta1.NamespaceAdoptionSpec{
+				Namespaces: []string{"legacy-by-name", "kube-system"},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"legacy": "adoption"},
+				},
+				DryRun: true,
+			},
+		},
+	}
+
+	byName := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "legacy-by-name",
+		},
+	}
+	byLabel := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "legacy-by-label",
+			Labels: map[string]string{"legacy": "adoption"},
+		},
+	}
+
+	JustBeforeEach(func() {
+		for _, ns := range []*corev1.Namespace{byName, byLabel} {
+			EventuallyCreation(func() error {
+				ns.ResourceVersion = ""
+				return k8sClient.Create(context.TODO(), ns)
+			}).Should(Succeed())
+		}
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		// the Tenant is deleted by the test, unless failed earlier
+		_ = k8sClient.Delete(context.TODO(), tnt)
+		for _, ns := range []*corev1.Namespace{byName, byLabel} {
+			_ = k8sClient.Delete(context.TODO(), ns)
+		}
+	})
+
+	It("should report and then adopt the Namespaces", func() {
+		By("reporting the changes in dry-run mode", func() {
+			Eventually(func() []string {
+				t := &capsulev1beta1.Tenant{}
+				_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t)
+
+				var names []string
+				for _, report := range t.Status.NamespaceAdoption {
+					if report.Adoptable {
+						names = append(names, report.Namespace)
+					}
+				}
+				return names
+			}, defaultTimeoutInterval, defaultPollInterval).Should(ConsistOf(byName.GetName(), byLabel.GetName()))
+
+			t := &capsulev1beta1.Tenant{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t)).Should(Succeed())
+			Expect(t.Status.NamespaceAdoption).Should(ContainElement(capsulev1beta1.NamespaceAdoptionReport{
+				Namespace: "kube-system",
+				Changes:   []string{"Namespace is reserved to the cluster components"},
+			}))
+
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: byName.GetName()}, ns)).Should(Succeed())
+			Expect(ns.GetOwnerReferences()).Should(BeEmpty())
+		})
+
+		By("adopting the Namespaces", func() {
+			Eventually(func() error {
+				t := &capsulev1beta1.Tenant{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t); err != nil {
+					return err
+				}
+				t.Spec.NamespaceAdoption.DryRun = false
+				return k8sClient.Update(context.TODO(), t)
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+			TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElements(byName.GetName(), byLabel.GetName()))
+		})
+
+		By("applying the Tenant resources", func() {
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(), types.NamespacedName{Name: "namespace:admin", Namespace: byLabel.GetName()}, &rbacv1.RoleBinding{})
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+
+		By("detaching the adopted Namespaces upon the Tenant deletion with the Cascade policy", func() {
+			Eventually(func() []string {
+				t := &capsulev1beta1.Tenant{}
+				_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t)
+				return t.GetFinalizers()
+			}, defaultTimeoutInterval, defaultPollInterval).Should(ContainElement(capsulev1beta1.OrphanNamespacesFinalizer))
+
+			Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, &capsulev1beta1.Tenant{}))
+			}, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+
+			for _, name := range []string{byName.GetName(), byLabel.GetName()} {
+				Consistently(func() error {
+					ns := &corev1.Namespace{}
+					if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: name}, ns); err != nil {
+						return err
+					}
+					if len(ns.GetOwnerReferences()) > 0 {
+						return errors.NewBadRequest("namespace still owned")
+					}
+					if _, ok := ns.GetAnnotations()[capsulev1beta1.AdoptedNamespaceAnnotation]; ok {
+						return errors.NewBadRequest("namespace still annotated")
+					}
+					return nil
+				}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+			}
+		})
+	})
+})
+
+var _ = Describe("adopting Namespaces with an empty selector", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-adoption-empty-selector",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "adele",
+					Kind: "User",
+				},
+			},
+			NamespaceAdoption: &capsulev1beta1.NamespaceAdoptionSpec{
+				Selector: &metav1.LabelSelector{},
+			},
+		},
+	}
+
+	It("should be denied", func() {
+		Expect(k8sClient.Create(context.TODO(), tnt)).ShouldNot(Succeed())
+	})
+})
@@ -0,0 +1,164 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("transferring a Namespace to a different Tenant", func() {
+	source := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-transfer-source",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "sylvia",
+					Kind: "User",
+				},
+			},
+		},
+	}
+	target := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-transfer-target",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "terence",
+					Kind: "User",
+				},
+			},
+		},
+	}
+
+	JustBeforeEach(func() {
+		for _, tnt := range []*capsulev1beta1.Tenant{source, target} {
+			EventuallyCreation(func() error {
+				tnt.ResourceVersion = ""
+				return k8sClient.Create(context.TODO(), tnt)
+			}).Should(Succeed())
+		}
+	})
+	JustAfterEach(func() {
+		for _, tnt := range []*capsulev1beta1.Tenant{source, target} {
+			Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+		}
+	})
+
+	relabel := func(name, tenant string) error {
+		ns := &corev1.Namespace{}
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: name}, ns); err != nil {
+			return err
+		}
+		ns.Labels["capsule.clastix.io/tenant"] = tenant
+		return k8sClient.Update(context.TODO(), ns)
+	}
+
+	It("should move the Namespace and its resources", func() {
+		ns := NewNamespace("transferred-namespace")
+		NamespaceCreation(ns, source.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(source, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		By("rejecting the transfer to a cordoned Tenant", func() {
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: target.GetName()}, target)).Should(Succeed())
+			target.Labels = map[string]string{"capsule.clastix.io/cordon": "enabled"}
+			Expect(k8sClient.Update(context.TODO(), target)).Should(Succeed())
+
+			Eventually(func() error {
+				return relabel(ns.GetName(), target.GetName())
+			}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: target.GetName()}, target)).Should(Succeed())
+			target.Labels = map[string]string{}
+			Expect(k8sClient.Update(context.TODO(), target)).Should(Succeed())
+		})
+
+		By("rejecting the transfer exceeding the resource quota of the target Tenant", func() {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "transferred", Namespace: ns.GetName()},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+			}
+			EventuallyCreation(func() error {
+				return k8sClient.Create(context.TODO(), svc)
+			}).Should(Succeed())
+
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: target.GetName()}, target)).Should(Succeed())
+			target.Spec.ResourceQuota = &capsulev1beta1.ResourceQuotaSpec{
+				Items: []capsulev1beta1.ResourceQuotaItem{
+					{ResourceQuotaSpec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceServices: resource.MustParse("0")}}},
+				},
+			}
+			Expect(k8sClient.Update(context.TODO(), target)).Should(Succeed())
+
+			Eventually(func() error {
+				return relabel(ns.GetName(), target.GetName())
+			}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: target.GetName()}, target)).Should(Succeed())
+			target.Spec.ResourceQuota = nil
+			Expect(k8sClient.Update(context.TODO(), target)).Should(Succeed())
+		})
+
+		By("transferring the Namespace", func() {
+			Eventually(func() error {
+				return relabel(ns.GetName(), target.GetName())
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+
+			TenantNamespaceList(target, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+			TenantNamespaceList(source, defaultTimeoutInterval).ShouldNot(ContainElement(ns.GetName()))
+		})
+
+		By("replacing the owner RoleBinding", func() {
+			Eventually(func() []rbacv1.Subject {
+				rb := &rbacv1.RoleBinding{}
+				_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: "namespace:admin", Namespace: ns.GetName()}, rb)
+				return rb.Subjects
+			}, defaultTimeoutInterval, defaultPollInterval).Should(ContainElement(rbacv1.Subject{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "User",
+				Name:     target.Spec.Owners[0].Name,
+			}))
+		})
+
+		By("removing the transfer marker once pruned", func() {
+			Eventually(func() map[string]string {
+				n := &corev1.Namespace{}
+				_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: ns.GetName()}, n)
+				return n.GetAnnotations()
+			}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(HaveKey(capsulev1beta1.TransferredNamespaceAnnotation))
+		})
+	})
+
+	It("should reject the transfer requested by a Tenant owner", func() {
+		ns := NewNamespace("non-transferred-namespace")
+		NamespaceCreation(ns, source.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(source, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		cs := ownerClient(source.Spec.Owners[0])
+		Eventually(func() error {
+			n, err := cs.CoreV1().Namespaces().Get(context.TODO(), ns.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			n.Labels["capsule.clastix.io/tenant"] = target.GetName()
+			_, err = cs.CoreV1().Namespaces().Update(context.TODO(), n, metav1.UpdateOptions{})
+			return err
+		}, defaultTimeoutInterval, defaultPollInterval).ShouldNot(Succeed())
+	})
+})
@@ -0,0 +1,115 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("creating NodePort services when the node ports are partitioned for Tenant", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-port-range",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "nora",
+					Kind: "User",
+				},
+			},
+			ServiceOptions: &capsulev1beta1.ServiceOptions{
+				NodePorts: &capsulev1beta1.NodePortsSpec{
+					Ranges: []capsulev1beta1.NodePortRange{{From: 30100, To: 30101}},
+				},
+			},
+		},
+	}
+
+	service := func(name string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{
+					{
+						Port: 9999,
+						TargetPort: intstr.IntOrString{
+							Type:   intstr.Int,
+							IntVal: 9999,
+						},
+						NodePort: nodePort,
+						Protocol: corev1.ProtocolTCP,
+					},
+				},
+			},
+		}
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should assign and enforce the allowed node ports", func() {
+		ns := NewNamespace("node-port-range")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		By("specifying a forbidden node port", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("forbidden", 30500), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("specifying an allowed node port", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("allowed", 30101), metav1.CreateOptions{})
+				return err
+			}).Should(Succeed())
+		})
+		By("not specifying the node port", func() {
+			var created *corev1.Service
+
+			EventuallyCreation(func() (err error) {
+				created, err = cs.CoreV1().Services(ns.Name).Create(context.Background(), service("assigned", 0), metav1.CreateOptions{})
+				return
+			}).Should(Succeed())
+
+			Expect(created.Spec.Ports[0].NodePort).Should(BeEquivalentTo(30100))
+		})
+		By("exhausting the allowed node ports", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Services(ns.Name).Create(context.Background(), service("exhausted", 0), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+	})
+
+	It("should reject the node ports outside of the cluster range", func() {
+		outside := tnt.DeepCopy()
+		outside.SetName("node-port-range-outside")
+		outside.Spec.ServiceOptions.NodePorts.Ranges = []capsulev1beta1.NodePortRange{{From: 32700, To: 32800}}
+
+		Expect(k8sClient.Create(context.TODO(), outside)).ShouldNot(Succeed())
+	})
+})
@@ -32,10 +32,12 @@ var _ = Describe("when Tenant owner interacts with the webhooks", func() {
 					Kind: "User",
 				},
 			},
-			StorageClasses: &capsulev1beta1.AllowedListSpec{
-				Exact: []string{
-					"cephfs",
-					"glusterfs",
+			StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{
+						"cephfs",
+						"glusterfs",
+					},
 				},
 			},
 			LimitRanges: &capsulev1beta1.LimitRangesSpec{Items: []corev1.LimitRangeSpec{
@@ -77,10 +79,12 @@ var _ = Describe("when Tenant owner interacts with the webhooks", func() {
 				},
 			},
 			},
-			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []corev1.ResourceQuotaSpec{
+			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []capsulev1beta1.ResourceQuotaItem{
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourcePods: resource.MustParse("10"),
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourcePods: resource.MustParse("10"),
+						},
 					},
 				},
 			},
@@ -29,9 +29,11 @@ var _ = Describe("enforcing a Priority Class", func() {
 					Kind: "User",
 				},
 			},
-			PriorityClasses: &capsulev1beta1.AllowedListSpec{
-				Exact: []string{"gold"},
-				Regex: "pc\\-\\w+",
+			PriorityClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"gold"},
+					Regex: "pc\\-\\w+",
+				},
 			},
 		},
 	}
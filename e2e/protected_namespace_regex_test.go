@@ -12,8 +12,6 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	capsulev1alpha1 "github.com/clastix/capsule/api/v1alpha1"
-
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
 
@@ -43,7 +41,7 @@ var _ = Describe("creating a Namespace with a protected Namespace regex enabled"
 	})
 
 	It("should succeed and be available in Tenant namespaces list", func() {
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.ProtectedNamespaceRegexpString = `^.*[-.]system$`
 		})
 
@@ -57,7 +55,7 @@ var _ = Describe("creating a Namespace with a protected Namespace regex enabled"
 		ns := NewNamespace("test-system")
 		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).ShouldNot(Succeed())
 
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.ProtectedNamespaceRegexpString = ""
 		})
 	})
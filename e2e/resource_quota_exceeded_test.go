@@ -79,26 +79,32 @@ var _ = Describe("exceeding a Tenant resource quota", func() {
 				},
 			},
 			},
-			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []corev1.ResourceQuotaSpec{
+			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []capsulev1beta1.ResourceQuotaItem{
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourceLimitsCPU:      resource.MustParse("8"),
-						corev1.ResourceLimitsMemory:   resource.MustParse("16Gi"),
-						corev1.ResourceRequestsCPU:    resource.MustParse("8"),
-						corev1.ResourceRequestsMemory: resource.MustParse("16Gi"),
-					},
-					Scopes: []corev1.ResourceQuotaScope{
-						corev1.ResourceQuotaScopeNotTerminating,
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceLimitsCPU:      resource.MustParse("8"),
+							corev1.ResourceLimitsMemory:   resource.MustParse("16Gi"),
+							corev1.ResourceRequestsCPU:    resource.MustParse("8"),
+							corev1.ResourceRequestsMemory: resource.MustParse("16Gi"),
+						},
+						Scopes: []corev1.ResourceQuotaScope{
+							corev1.ResourceQuotaScopeNotTerminating,
+						},
 					},
 				},
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourcePods: resource.MustParse("10"),
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourcePods: resource.MustParse("10"),
+						},
 					},
 				},
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+						},
 					},
 				},
 			},
@@ -160,6 +166,19 @@ var _ = Describe("exceeding a Tenant resource quota", func() {
 				return
 			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
 		}
+		By("ensuring the Tenant usage has reached the hard quota", func() {
+			Eventually(func() bool {
+				t := &capsulev1beta1.Tenant{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t); err != nil {
+					return false
+				}
+				status := t.Status.GetResourceQuotaStatus(1)
+				if status == nil {
+					return false
+				}
+				return status.Used.Pods().Cmp(*status.Hard.Pods()) == 0
+			}, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		})
 		for _, ns := range nsl {
 			n := fmt.Sprintf("capsule-%s-1", tnt.GetName())
 			rq := &corev1.ResourceQuota{}
@@ -168,11 +187,11 @@ var _ = Describe("exceeding a Tenant resource quota", func() {
 					return k8sClient.Get(context.TODO(), types.NamespacedName{Name: n, Namespace: ns}, rq)
 				}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
 			})
-			By("ensuring the status has been blocked with actual usage", func() {
-				Eventually(func() bool {
+			By("ensuring the Tenant usage is reported for compatibility", func() {
+				Eventually(func() string {
 					_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: n, Namespace: ns}, rq)
-					return rq.Status.Hard.Pods().String() == rq.Status.Used.Pods().String()
-				}, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+					return rq.GetAnnotations()[capsulev1beta1.UsedQuotaFor(corev1.ResourcePods)]
+				}, defaultTimeoutInterval, defaultPollInterval).Should(Equal("10"))
 			})
 			By("creating an exceeded Pod", func() {
 				pod := &corev1.Pod{
@@ -0,0 +1,116 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("enforcing a Namespace scoped resource quota", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-namespace-scoped-quota",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "gordon",
+					Kind: "User",
+				},
+			},
+			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []capsulev1beta1.ResourceQuotaItem{
+				{
+					Scope: capsulev1beta1.ResourceQuotaScopeNamespace,
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceServicesLoadBalancers: resource.MustParse("1"),
+						},
+					},
+				},
+			},
+			},
+		},
+	}
+
+	nsl := []string{"scoped-quota-first", "scoped-quota-second"}
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+		By("creating the Namespaces", func() {
+			for _, i := range nsl {
+				ns := NewNamespace(i)
+				NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+				TenantNamespaceList(tnt, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+			}
+		})
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should apply the hard quota to each Namespace", func() {
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		newService := func(name string) *corev1.Service {
+			return &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       9999,
+							TargetPort: intstr.FromInt(9999),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+				},
+			}
+		}
+
+		for _, ns := range nsl {
+			By(fmt.Sprintf("copying verbatim the Resource Quota in the %s Namespace", ns), func() {
+				rq := &corev1.ResourceQuota{}
+				Eventually(func() error {
+					return k8sClient.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("capsule-%s-0", tnt.GetName()), Namespace: ns}, rq)
+				}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+				Expect(rq.Spec).Should(Equal(tnt.Spec.ResourceQuota.Items[0].ResourceQuotaSpec))
+				Expect(rq.GetAnnotations()).ShouldNot(HaveKey(capsulev1beta1.UsedQuotaFor(corev1.ResourceServicesLoadBalancers)))
+			})
+			By(fmt.Sprintf("creating a LoadBalancer Service in the %s Namespace", ns), func() {
+				EventuallyCreation(func() error {
+					_, err := cs.CoreV1().Services(ns).Create(context.TODO(), newService("first"), metav1.CreateOptions{})
+					return err
+				}).Should(Succeed())
+			})
+			By(fmt.Sprintf("exceeding the quota in the %s Namespace", ns), func() {
+				EventuallyCreation(func() error {
+					_, err := cs.CoreV1().Services(ns).Create(context.TODO(), newService("second"), metav1.CreateOptions{})
+					return err
+				}).ShouldNot(Succeed())
+			})
+		}
+		By("ensuring the usage is not aggregated in the Tenant status", func() {
+			t := &capsulev1beta1.Tenant{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: tnt.GetName()}, t)).Should(Succeed())
+			Expect(t.Status.GetResourceQuotaStatus(0)).Should(BeNil())
+		})
+	})
+})
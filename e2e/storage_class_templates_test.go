@@ -0,0 +1,207 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("when Tenant handles PersistentVolumeClaim templates", func() {
+	maxSize := resource.MustParse("5Gi")
+
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "storage-templates",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "stella",
+					Kind: "User",
+				},
+			},
+			StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"cephfs"},
+				},
+			},
+			PersistentVolumeClaims: &capsulev1beta1.PersistentVolumeClaimsSpec{
+				MaxSize:        &maxSize,
+				AllowExpansion: pointer.BoolPtr(false),
+			},
+		},
+	}
+
+	claimSpec := func(storageClass, size string) corev1.PersistentVolumeClaimSpec {
+		return corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.StringPtr(storageClass),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		}
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should validate the generic ephemeral volumes of Pods", func() {
+		maj, min, v := GetKubernetesSemVer()
+		if maj == 1 && min < 21 {
+			Skip("Running test on Kubernetes " + v + ", doesn't enable generic ephemeral volumes by default")
+		}
+
+		ns := NewNamespace("storage-ephemeral")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		pod := func(name string, spec corev1.PersistentVolumeClaimSpec) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "container",
+							Image: "quay.io/google-containers/pause-amd64:3.0",
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "scratch",
+							VolumeSource: corev1.VolumeSource{
+								Ephemeral: &corev1.EphemeralVolumeSource{
+									VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+										Spec: spec,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		By("using a forbidden Storage Class", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Pods(ns.GetName()).Create(context.Background(), pod("forbidden-class", claimSpec("glusterfs", "1Gi")), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("exceeding the maximum size", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Pods(ns.GetName()).Create(context.Background(), pod("exceeding-size", claimSpec("cephfs", "10Gi")), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("using an allowed Storage Class and size", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.CoreV1().Pods(ns.GetName()).Create(context.Background(), pod("allowed", claimSpec("cephfs", "1Gi")), metav1.CreateOptions{})
+				return err
+			}).Should(Succeed())
+		})
+	})
+
+	It("should validate the volumeClaimTemplates of StatefulSets", func() {
+		ns := NewNamespace("storage-statefulset")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		statefulSet := func(name string, spec corev1.PersistentVolumeClaimSpec) *appsv1.StatefulSet {
+			return &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": name},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": name},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "container",
+									Image: "quay.io/google-containers/pause-amd64:3.0",
+								},
+							},
+						},
+					},
+					VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "data",
+							},
+							Spec: spec,
+						},
+					},
+				},
+			}
+		}
+
+		By("using a forbidden Storage Class", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.AppsV1().StatefulSets(ns.GetName()).Create(context.Background(), statefulSet("forbidden-class", claimSpec("glusterfs", "1Gi")), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("exceeding the maximum size", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.AppsV1().StatefulSets(ns.GetName()).Create(context.Background(), statefulSet("exceeding-size", claimSpec("cephfs", "10Gi")), metav1.CreateOptions{})
+				return err
+			}).ShouldNot(Succeed())
+		})
+		By("using an allowed Storage Class and size", func() {
+			EventuallyCreation(func() error {
+				_, err := cs.AppsV1().StatefulSets(ns.GetName()).Create(context.Background(), statefulSet("allowed", claimSpec("cephfs", "1Gi")), metav1.CreateOptions{})
+				return err
+			}).Should(Succeed())
+		})
+	})
+
+	It("should fail creating PersistentVolumeClaims exceeding the maximum size", func() {
+		ns := NewNamespace("storage-max-size")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "exceeding-size",
+			},
+			Spec: claimSpec("cephfs", "10Gi"),
+		}
+
+		EventuallyCreation(func() error {
+			_, err := cs.CoreV1().PersistentVolumeClaims(ns.GetName()).Create(context.Background(), pvc, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+})
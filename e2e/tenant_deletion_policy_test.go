@@ -0,0 +1,122 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("deleting a Tenant according to its deletion policy", func() {
+	deny := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-deletion-deny",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "denise",
+					Kind: "User",
+				},
+			},
+			DeletionPolicy: capsulev1beta1.DeletionPolicyDeny,
+		},
+	}
+	orphan := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant-deletion-orphan",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "oscar",
+					Kind: "User",
+				},
+			},
+			DeletionPolicy: capsulev1beta1.DeletionPolicyOrphan,
+		},
+	}
+
+	JustBeforeEach(func() {
+		for _, tnt := range []*capsulev1beta1.Tenant{deny, orphan} {
+			EventuallyCreation(func() error {
+				tnt.ResourceVersion = ""
+				return k8sClient.Create(context.TODO(), tnt)
+			}).Should(Succeed())
+		}
+	})
+
+	It("should block the deletion of a Tenant with Namespaces", func() {
+		ns := NewNamespace("deletion-deny")
+		NamespaceCreation(ns, deny.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(deny, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		Expect(k8sClient.Delete(context.TODO(), deny)).ShouldNot(Succeed())
+
+		By("deleting the Namespaces first", func() {
+			Expect(k8sClient.Delete(context.TODO(), ns)).Should(Succeed())
+			Eventually(func() error {
+				return k8sClient.Delete(context.TODO(), deny)
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+
+		Expect(k8sClient.Delete(context.TODO(), orphan)).Should(Succeed())
+	})
+
+	It("should keep the Namespaces of an orphaning Tenant", func() {
+		ns := NewNamespace("deletion-orphan")
+		NamespaceCreation(ns, orphan.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+		TenantNamespaceList(orphan, defaultTimeoutInterval).Should(ContainElement(ns.GetName()))
+
+		By("waiting for the finalizer", func() {
+			Eventually(func() []string {
+				t := &capsulev1beta1.Tenant{}
+				_ = k8sClient.Get(context.TODO(), types.NamespacedName{Name: orphan.GetName()}, t)
+				return t.GetFinalizers()
+			}, defaultTimeoutInterval, defaultPollInterval).Should(ContainElement(capsulev1beta1.OrphanNamespacesFinalizer))
+		})
+
+		By("rejecting the foreground deletion", func() {
+			Expect(k8sClient.Delete(context.TODO(), orphan, client.PropagationPolicy(metav1.DeletePropagationForeground))).ShouldNot(Succeed())
+		})
+
+		Expect(k8sClient.Delete(context.TODO(), orphan)).Should(Succeed())
+
+		By("waiting for the Tenant deletion", func() {
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(context.TODO(), types.NamespacedName{Name: orphan.GetName()}, &capsulev1beta1.Tenant{}))
+			}, defaultTimeoutInterval, defaultPollInterval).Should(BeTrue())
+		})
+
+		By("ensuring the Namespace has been detached", func() {
+			Consistently(func() error {
+				n := &corev1.Namespace{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: ns.GetName()}, n); err != nil {
+					return err
+				}
+				if len(n.GetOwnerReferences()) > 0 {
+					return errors.NewBadRequest("namespace still owned")
+				}
+				if _, ok := n.GetLabels()["capsule.clastix.io/tenant"]; ok {
+					return errors.NewBadRequest("namespace still labeled")
+				}
+				return nil
+			}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
+		})
+
+		Expect(k8sClient.Delete(context.TODO(), ns)).Should(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), deny)).Should(Succeed())
+	})
+})
@@ -13,7 +13,6 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	capsulev1alpha1 "github.com/clastix/capsule/api/v1alpha1"
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
 
@@ -45,7 +44,7 @@ var _ = Describe("when a second Tenant contains an already declared allowed Ingr
 	JustAfterEach(func() {
 		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
 
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.AllowTenantIngressHostnamesCollision = false
 		})
 	})
@@ -90,7 +89,7 @@ var _ = Describe("when a second Tenant contains an already declared allowed Ingr
 	It("should not block creation if contains collided Ingress hostnames", func() {
 		var cleanupFuncs []func()
 
-		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1alpha1.CapsuleConfiguration) {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
 			configuration.Spec.AllowTenantIngressHostnamesCollision = true
 		})
 
@@ -7,6 +7,7 @@ package e2e
 
 import (
 	"context"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,21 +17,48 @@ import (
 )
 
 var _ = Describe("creating a Tenant with wrong name", func() {
-	tnt := &capsulev1beta1.Tenant{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "non_rfc_dns_1123",
-		},
-		Spec: capsulev1beta1.TenantSpec{
-			Owners: capsulev1beta1.OwnerListSpec{
-				{
-					Name: "john",
-					Kind: "User",
+	newTenant := func(name string) *capsulev1beta1.Tenant {
+		return &capsulev1beta1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: capsulev1beta1.TenantSpec{
+				Owners: capsulev1beta1.OwnerListSpec{
+					{
+						Name: "john",
+						Kind: "User",
+					},
 				},
 			},
-		},
+		}
 	}
 
+	JustAfterEach(func() {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
+			configuration.Spec.TenantNameMaxLength = 63
+			configuration.Spec.ReservedTenantNames = nil
+		})
+	})
+
 	It("should fail", func() {
-		Expect(k8sClient.Create(context.TODO(), tnt)).ShouldNot(Succeed())
+		for _, name := range []string{"non_rfc_dns_1123", "non-rfc.dns-1123", "Non-rfc-dns-1123", strings.Repeat("a", 64)} {
+			Expect(k8sClient.Create(context.TODO(), newTenant(name))).ShouldNot(Succeed())
+		}
+	})
+
+	It("should fail exceeding the configured maximum length", func() {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
+			configuration.Spec.TenantNameMaxLength = 10
+		})
+
+		Expect(k8sClient.Create(context.TODO(), newTenant("too-long-tenant-name"))).ShouldNot(Succeed())
+	})
+
+	It("should fail using a reserved name", func() {
+		ModifyCapsuleConfigurationOpts(func(configuration *capsulev1beta1.CapsuleConfiguration) {
+			configuration.Spec.ReservedTenantNames = []string{"reserved-tenant"}
+		})
+
+		Expect(k8sClient.Create(context.TODO(), newTenant("reserved-tenant"))).ShouldNot(Succeed())
 	})
 })
@@ -127,26 +127,32 @@ var _ = Describe("creating namespaces within a Tenant with resources", func() {
 			NodeSelector: map[string]string{
 				"kubernetes.io/os": "linux",
 			},
-			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []corev1.ResourceQuotaSpec{
+			ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []capsulev1beta1.ResourceQuotaItem{
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourceLimitsCPU:      resource.MustParse("8"),
-						corev1.ResourceLimitsMemory:   resource.MustParse("16Gi"),
-						corev1.ResourceRequestsCPU:    resource.MustParse("8"),
-						corev1.ResourceRequestsMemory: resource.MustParse("16Gi"),
-					},
-					Scopes: []corev1.ResourceQuotaScope{
-						corev1.ResourceQuotaScopeNotTerminating,
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceLimitsCPU:      resource.MustParse("8"),
+							corev1.ResourceLimitsMemory:   resource.MustParse("16Gi"),
+							corev1.ResourceRequestsCPU:    resource.MustParse("8"),
+							corev1.ResourceRequestsMemory: resource.MustParse("16Gi"),
+						},
+						Scopes: []corev1.ResourceQuotaScope{
+							corev1.ResourceQuotaScopeNotTerminating,
+						},
 					},
 				},
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourcePods: resource.MustParse("10"),
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourcePods: resource.MustParse("10"),
+						},
 					},
 				},
 				{
-					Hard: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+					ResourceQuotaSpec: corev1.ResourceQuotaSpec{
+						Hard: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+						},
 					},
 				},
 			},
@@ -209,7 +215,7 @@ var _ = Describe("creating namespaces within a Tenant with resources", func() {
 					Eventually(func() error {
 						return k8sClient.Get(context.TODO(), types.NamespacedName{Name: n, Namespace: name}, rq)
 					}, defaultTimeoutInterval, defaultPollInterval).Should(Succeed())
-					Expect(rq.Spec).Should(Equal(s))
+					Expect(rq.Spec).Should(Equal(s.ResourceQuotaSpec))
 				}
 			})
 		}
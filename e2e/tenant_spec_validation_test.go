@@ -0,0 +1,70 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("creating a Tenant with an invalid specification", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "invalid-spec",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "system:serviceaccount:missing-name",
+					Kind: "ServiceAccount",
+				},
+			},
+			NamespacesMetadata: &capsulev1beta1.AdditionalMetadataSpec{
+				AdditionalLabels: map[string]string{
+					"invalid label": "value",
+				},
+			},
+			IngressClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Regex: "(invalid",
+				},
+			},
+			AdditionalRoleBindings: []capsulev1beta1.AdditionalRoleBindingsSpec{
+				{
+					ClusterRoleName: "not-existing-cluster-role",
+					Subjects: []rbacv1.Subject{
+						{
+							Kind: "User",
+							Name: "alice",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	It("should be rejected reporting all the invalid fields", func() {
+		err := k8sClient.Create(context.TODO(), tnt)
+		Expect(err).Should(HaveOccurred())
+		Expect(apierrors.IsInvalid(err)).Should(BeTrue())
+
+		for _, path := range []string{
+			"spec.owners[0].name",
+			"spec.namespacesMetadata.additionalLabels",
+			"spec.ingressClasses.allowedRegex",
+			"spec.additionalRoleBindings[0].clusterRoleName",
+		} {
+			Expect(err.Error()).Should(ContainSubstring(path))
+		}
+	})
+})
@@ -0,0 +1,155 @@
+//+build e2e
+
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var _ = Describe("enforcing Pod policies on workload controllers", func() {
+	tnt := &capsulev1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workload-validation",
+		},
+		Spec: capsulev1beta1.TenantSpec{
+			Owners: capsulev1beta1.OwnerListSpec{
+				{
+					Name: "wendy",
+					Kind: "User",
+				},
+			},
+			ContainerRegistries: &capsulev1beta1.AllowedListSpec{
+				Exact: []string{"docker.io"},
+			},
+			ImagePullPolicies: []capsulev1beta1.ImagePullPolicySpec{"Always"},
+			PriorityClasses: &capsulev1beta1.DefaultAllowedListSpec{
+				AllowedListSpec: capsulev1beta1.AllowedListSpec{
+					Exact: []string{"gold"},
+				},
+			},
+		},
+	}
+
+	template := func(image string, pullPolicy corev1.PullPolicy, priorityClassName string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": "workload"},
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy:     corev1.RestartPolicyNever,
+				PriorityClassName: priorityClassName,
+				Containers: []corev1.Container{
+					{
+						Name:            "container",
+						Image:           image,
+						ImagePullPolicy: pullPolicy,
+					},
+				},
+			},
+		}
+	}
+
+	JustBeforeEach(func() {
+		EventuallyCreation(func() error {
+			tnt.ResourceVersion = ""
+			return k8sClient.Create(context.TODO(), tnt)
+		}).Should(Succeed())
+	})
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.TODO(), tnt)).Should(Succeed())
+	})
+
+	It("should deny a Deployment using a forbidden registry", func() {
+		ns := NewNamespace("workload-registry")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		deployment := func(name, image string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "workload"},
+					},
+					Template: func() corev1.PodTemplateSpec {
+						tpl := template(image, corev1.PullAlways, "")
+						tpl.Spec.RestartPolicy = corev1.RestartPolicyAlways
+						return tpl
+					}(),
+				},
+			}
+		}
+
+		EventuallyCreation(func() error {
+			_, err := cs.AppsV1().Deployments(ns.Name).Create(context.Background(), deployment("denied", "gcr.io/google_containers/pause-amd64:3.0"), metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+		EventuallyCreation(func() error {
+			_, err := cs.AppsV1().Deployments(ns.Name).Create(context.Background(), deployment("allowed", "docker.io/nginx:alpine"), metav1.CreateOptions{})
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should deny a Job using a forbidden pull policy", func() {
+		ns := NewNamespace("workload-pullpolicy")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "job",
+			},
+			Spec: batchv1.JobSpec{
+				Template: template("docker.io/busybox:latest", corev1.PullIfNotPresent, ""),
+			},
+		}
+
+		EventuallyCreation(func() error {
+			_, err := cs.BatchV1().Jobs(ns.Name).Create(context.Background(), job, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+
+	It("should deny a CronJob using a forbidden Priority Class", func() {
+		ns := NewNamespace("workload-priorityclass")
+		NamespaceCreation(ns, tnt.Spec.Owners[0], defaultTimeoutInterval).Should(Succeed())
+
+		cs := ownerClient(tnt.Spec.Owners[0])
+
+		cronJob := &batchv1beta1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cronjob",
+			},
+			Spec: batchv1beta1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+				JobTemplate: batchv1beta1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: template("docker.io/busybox:latest", corev1.PullAlways, "system-node-critical"),
+					},
+				},
+			},
+		}
+
+		EventuallyCreation(func() error {
+			_, err := cs.BatchV1beta1().CronJobs(ns.Name).Create(context.Background(), cronJob, metav1.CreateOptions{})
+			return err
+		}).ShouldNot(Succeed())
+	})
+})
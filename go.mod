@@ -3,6 +3,7 @@ module github.com/clastix/capsule
 go 1.16
 
 require (
+	github.com/evanphx/json-patch v4.9.0+incompatible
 	github.com/go-logr/logr v0.3.0
 	github.com/hashicorp/go-multierror v1.1.0
 	github.com/onsi/ginkgo v1.14.1
@@ -11,6 +12,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.6.1
 	go.uber.org/zap v1.15.0
+	gomodules.xyz/jsonpatch/v2 v2.1.0
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
 	k8s.io/api v0.20.2
 	k8s.io/apiextensions-apiserver v0.20.1
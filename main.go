@@ -4,8 +4,10 @@
 package main
 
 import (
+	"context"
 	goflag "flag"
 	"fmt"
+	"net/http"
 	"os"
 	goRuntime "runtime"
 
@@ -36,6 +38,7 @@ import (
 	"github.com/clastix/capsule/pkg/webhook/ownerreference"
 	"github.com/clastix/capsule/pkg/webhook/pod"
 	"github.com/clastix/capsule/pkg/webhook/pvc"
+	"github.com/clastix/capsule/pkg/webhook/quota"
 	"github.com/clastix/capsule/pkg/webhook/route"
 	"github.com/clastix/capsule/pkg/webhook/service"
 	"github.com/clastix/capsule/pkg/webhook/tenant"
@@ -70,6 +73,7 @@ func main() {
 	var enableLeaderElection bool
 	var version bool
 	var namespace, configurationName string
+	var maxConcurrentReconciles, namespaceWorkers int
 	var goFlagSet goflag.FlagSet
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
@@ -78,6 +82,8 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&version, "version", false, "Print the Capsule version and exit")
 	flag.StringVar(&configurationName, "configuration-name", "default", "The CapsuleConfiguration resource name to use")
+	flag.IntVar(&maxConcurrentReconciles, "tenant-max-concurrent-reconciles", 1, "The maximum number of Tenant resources reconciled concurrently")
+	flag.IntVar(&namespaceWorkers, "tenant-namespace-workers", 10, "The maximum number of Namespaces of a single Tenant synchronized concurrently")
 
 	opts := zap.Options{
 		EncoderConfigOptions: append([]zap.EncoderConfigOption{}, func(config *zapcore.EncoderConfig) {
@@ -106,6 +112,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if maxConcurrentReconciles < 1 || namespaceWorkers < 1 {
+		setupLog.Error(fmt.Errorf("the Tenant concurrency settings must be greater than zero"), "unable to start manager")
+		os.Exit(1)
+	}
+
 	manager, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -128,11 +139,29 @@ func main() {
 	_ = manager.AddReadyzCheck("ping", healthz.Ping)
 	_ = manager.AddHealthzCheck("ping", healthz.Ping)
 
+	cfg, err := configuration.NewCachedCapsuleConfiguration(context.Background(), manager.GetCache(), configurationName, ctrl.Log.WithName("configuration"))
+	if err != nil {
+		setupLog.Error(err, "unable to setup the Capsule configuration")
+		os.Exit(1)
+	}
+	// the webhooks must not be served with the default configuration, until the CapsuleConfiguration is loaded
+	_ = manager.AddReadyzCheck("configuration", func(*http.Request) error {
+		if !cfg.HasSynced() {
+			return fmt.Errorf("CapsuleConfiguration %s has not been loaded yet", configurationName)
+		}
+
+		return nil
+	})
+
 	if err = (&controllers.TenantReconciler{
-		Client:   manager.GetClient(),
-		Log:      ctrl.Log.WithName("controllers").WithName("Tenant"),
-		Scheme:   manager.GetScheme(),
-		Recorder: manager.GetEventRecorderFor("tenant-controller"),
+		Client:                  manager.GetClient(),
+		Log:                     ctrl.Log.WithName("controllers").WithName("Tenant"),
+		Scheme:                  manager.GetScheme(),
+		Recorder:                manager.GetEventRecorderFor("tenant-controller"),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		NamespaceWorkers:        namespaceWorkers,
+		Configuration:           cfg,
+		CapsuleNamespace:        namespace,
 	}).SetupWithManager(manager); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tenant")
 		os.Exit(1)
@@ -141,22 +170,29 @@ func main() {
 		setupLog.Error(err, "unable to create conversion webhook", "webhook", "Tenant")
 		os.Exit(1)
 	}
+	if err = (&capsulev1alpha1.CapsuleConfiguration{}).SetupWebhookWithManager(manager); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "CapsuleConfiguration")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
-	cfg := configuration.NewCapsuleConfiguration(manager.GetClient(), configurationName)
-
 	// webhooks: the order matters, don't change it and just append
 	webhooksList := append(
 		make([]webhook.Webhook, 0),
-		route.Pod(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.PriorityClass()),
+		route.Pod(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.ContainerImages(), pod.PriorityClass(), pvc.Templates(), quota.Handler(manager.GetAPIReader())),
 		route.Namespace(utils.InCapsuleGroups(cfg, namespacewebhook.QuotaHandler(), namespacewebhook.FreezeHandler(cfg), namespacewebhook.PrefixHandler(cfg))),
 		route.Ingress(ingress.Class(cfg), ingress.Hostnames(cfg), ingress.Collision(cfg)),
-		route.PVC(pvc.Handler()),
-		route.Service(service.Handler()),
+		route.PVC(pvc.Handler(), quota.Handler(manager.GetAPIReader())),
+		route.Service(service.Handler(), utils.InCapsuleGroups(cfg, service.StatusHandler()), quota.Handler(manager.GetAPIReader())),
 		route.NetworkPolicy(utils.InCapsuleGroups(cfg, networkpolicy.Handler())),
-		route.Tenant(tenant.NameHandler(), tenant.IngressClassRegexHandler(), tenant.StorageClassRegexHandler(), tenant.ContainerRegistryRegexHandler(), tenant.HostnameRegexHandler(), tenant.HostnamesCollisionHandler(cfg), tenant.FreezedEmitter()),
-		route.OwnerReference(utils.InCapsuleGroups(cfg, ownerreference.Handler(cfg))),
+		route.Tenant(tenant.NameHandler(cfg), tenant.Validator(cfg), tenant.HostnamesCollisionHandler(cfg), tenant.FreezedEmitter(), tenant.DeletionPolicyHandler()),
+		route.OwnerReference(utils.InCapsuleGroups(cfg, ownerreference.Handler(cfg)), ownerreference.TransferHandler(cfg)),
 		route.Cordoning(tenant.CordoningHandler(cfg)),
+		route.PodMutating(pod.RegistryRewrite(), pod.ImagePullPolicyDefault(), pod.PriorityClassDefault()),
+		route.PVCMutating(pvc.StorageClassDefault()),
+		route.IngressMutating(ingress.DefaultClass()),
+		route.ServiceMutating(service.NodePortDefault(cfg)),
+		route.Workload(pod.ImagePullPolicy(), pod.ContainerRegistry(), pod.ContainerImages(), pod.PriorityClass(), pvc.Templates()),
 	)
 	if err = webhook.Register(manager, webhooksList...); err != nil {
 		setupLog.Error(err, "unable to setup webhooks")
@@ -216,7 +252,8 @@ func main() {
 	}
 
 	if err = (&config.Manager{
-		Log: ctrl.Log.WithName("controllers").WithName("CapsuleConfiguration"),
+		Log:      ctrl.Log.WithName("controllers").WithName("CapsuleConfiguration"),
+		Recorder: manager.GetEventRecorderFor("capsuleconfiguration-controller"),
 	}).SetupWithManager(manager, configurationName); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CapsuleConfiguration")
 		os.Exit(1)
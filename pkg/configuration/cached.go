@@ -0,0 +1,185 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"context"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// snapshot is the immutable view of the Capsule Configuration at a given time,
+// holding the already compiled protected Namespace regexp.
+type snapshot struct {
+	spec                     capsulev1beta1.CapsuleConfigurationSpec
+	protectedNamespaceRegexp *regexp.Regexp
+	protectedNamespaceErr    error
+	nodePortRange            capsulev1beta1.NodePortRange
+}
+
+func newSnapshot(spec capsulev1beta1.CapsuleConfigurationSpec) *snapshot {
+	s := &snapshot{spec: spec}
+	// the range is defaulted by the API server, unless the CapsuleConfiguration has been created through v1alpha1
+	portRange := spec.NodePortRange
+	if len(portRange) == 0 {
+		portRange = DefaultNodePortRange
+	}
+	// the range has been validated before the snapshot creation
+	s.nodePortRange, _ = parseNodePortRange(portRange)
+
+	if expr := spec.ProtectedNamespaceRegexpString; len(expr) > 0 {
+		s.protectedNamespaceRegexp, s.protectedNamespaceErr = regexp.Compile(expr)
+		if s.protectedNamespaceErr != nil {
+			s.protectedNamespaceErr = errors.Wrap(s.protectedNamespaceErr, "Cannot compile the protected namespace regexp")
+		}
+	}
+
+	return s
+}
+
+// cachedConfiguration is the Capsule Configuration retrieval mode backed by the
+// CapsuleConfiguration informer: the snapshot is swapped atomically upon each event,
+// avoiding any API Server round-trip when serving the admission requests.
+type cachedConfiguration struct {
+	name     string
+	log      logr.Logger
+	snapshot atomic.Value
+	// loaded is set once the CapsuleConfiguration has been received, or found missing upon the informer sync.
+	loaded int32
+	// informerSynced and reader are used to find out if the CapsuleConfiguration is missing.
+	informerSynced func() bool
+	reader         client.Reader
+}
+
+func NewCachedCapsuleConfiguration(ctx context.Context, informers cache.Cache, name string, log logr.Logger) (Configuration, error) {
+	c := &cachedConfiguration{
+		name:   name,
+		log:    log,
+		reader: informers,
+	}
+	c.snapshot.Store(newSnapshot(defaultSpec()))
+
+	informer, err := informers.GetInformer(ctx, &capsulev1beta1.CapsuleConfiguration{})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the CapsuleConfiguration informer")
+	}
+	c.informerSynced = informer.HasSynced
+
+	informer.AddEventHandler(toolscache.FilteringResourceEventHandler{
+		FilterFunc: c.filterByName,
+		Handler: toolscache.ResourceEventHandlerFuncs{
+			AddFunc: c.store,
+			UpdateFunc: func(_, newObj interface{}) {
+				c.store(newObj)
+			},
+			DeleteFunc: func(interface{}) {
+				// falling back to defaults would silently loosen the policies, such as the protected Namespaces
+				c.log.Info("CapsuleConfiguration has been deleted, keeping the last valid one", "name", c.name)
+			},
+		},
+	})
+
+	return c, nil
+}
+
+func (c *cachedConfiguration) filterByName(obj interface{}) bool {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	cfg, ok := obj.(*capsulev1beta1.CapsuleConfiguration)
+
+	return ok && cfg.GetName() == c.name
+}
+
+func (c *cachedConfiguration) store(obj interface{}) {
+	cfg := obj.(*capsulev1beta1.CapsuleConfiguration)
+
+	defer atomic.StoreInt32(&c.loaded, 1)
+
+	// An invalid configuration is never applied: the last valid one is kept in place,
+	// the validation errors are reported by the CapsuleConfiguration controller.
+	if errs := Validate(cfg.Spec); len(errs) > 0 {
+		c.log.Error(errs.ToAggregate(), "CapsuleConfiguration is invalid, keeping the last valid one", "name", c.name)
+
+		return
+	}
+
+	c.snapshot.Store(newSnapshot(*cfg.Spec.DeepCopy()))
+}
+
+// HasSynced returns true once the CapsuleConfiguration has been loaded, or when it's missing and the defaults apply:
+// until then, the snapshot holds the defaults, that must not be used to serve the admission requests.
+func (c *cachedConfiguration) HasSynced() bool {
+	if atomic.LoadInt32(&c.loaded) == 1 {
+		return true
+	}
+
+	if !c.informerSynced() {
+		return false
+	}
+	// the informer has synced, but the event handler could have not yet received the CapsuleConfiguration
+	err := c.reader.Get(context.Background(), types.NamespacedName{Name: c.name}, &capsulev1beta1.CapsuleConfiguration{})
+	if !apierrors.IsNotFound(err) {
+		return false
+	}
+
+	atomic.StoreInt32(&c.loaded, 1)
+
+	return true
+}
+
+func (c *cachedConfiguration) load() *snapshot {
+	return c.snapshot.Load().(*snapshot)
+}
+
+func (c *cachedConfiguration) AllowIngressHostnameCollision() bool {
+	return c.load().spec.AllowIngressHostnameCollision
+}
+
+func (c *cachedConfiguration) AllowTenantIngressHostnamesCollision() bool {
+	return c.load().spec.AllowTenantIngressHostnamesCollision
+}
+
+func (c *cachedConfiguration) ProtectedNamespaceRegexp() (*regexp.Regexp, error) {
+	s := c.load()
+
+	return s.protectedNamespaceRegexp, s.protectedNamespaceErr
+}
+
+func (c *cachedConfiguration) ForceTenantPrefix() bool {
+	return c.load().spec.ForceTenantPrefix
+}
+
+func (c *cachedConfiguration) UserGroups() []string {
+	return c.load().spec.UserGroups
+}
+
+func (c *cachedConfiguration) TenantNameMaxLength() int {
+	// the field is defaulted by the API server, unless the CapsuleConfiguration has been created through v1alpha1
+	if maxLength := c.load().spec.TenantNameMaxLength; maxLength > 0 {
+		return int(maxLength)
+	}
+
+	return validation.DNS1123LabelMaxLength
+}
+
+func (c *cachedConfiguration) ReservedTenantNames() []string {
+	return c.load().spec.ReservedTenantNames
+}
+
+func (c *cachedConfiguration) NodePortRange() capsulev1beta1.NodePortRange {
+	return c.load().nodePortRange
+}
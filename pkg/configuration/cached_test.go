@@ -0,0 +1,131 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func newCapsuleConfiguration(spec capsulev1beta1.CapsuleConfigurationSpec) *capsulev1beta1.CapsuleConfiguration {
+	return &capsulev1beta1.CapsuleConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       spec,
+	}
+}
+
+func newCachedConfiguration(objs ...runtime.Object) *cachedConfiguration {
+	scheme := runtime.NewScheme()
+	_ = capsulev1beta1.AddToScheme(scheme)
+
+	c := &cachedConfiguration{
+		name:           "default",
+		log:            log.Log,
+		informerSynced: func() bool { return true },
+		reader:         fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+	}
+	c.snapshot.Store(newSnapshot(defaultSpec()))
+
+	return c
+}
+
+func TestNewSnapshot(t *testing.T) {
+	s := newSnapshot(capsulev1beta1.CapsuleConfigurationSpec{ProtectedNamespaceRegexpString: "^kube-.*"})
+	assert.NoError(t, s.protectedNamespaceErr)
+	assert.True(t, s.protectedNamespaceRegexp.MatchString("kube-system"))
+
+	s = newSnapshot(capsulev1beta1.CapsuleConfigurationSpec{ProtectedNamespaceRegexpString: "["})
+	assert.Error(t, s.protectedNamespaceErr)
+	assert.Nil(t, s.protectedNamespaceRegexp)
+
+	s = newSnapshot(capsulev1beta1.CapsuleConfigurationSpec{})
+	assert.NoError(t, s.protectedNamespaceErr)
+	assert.Nil(t, s.protectedNamespaceRegexp)
+}
+
+func TestCachedConfigurationStore(t *testing.T) {
+	c := newCachedConfiguration()
+
+	assert.Equal(t, []string{"capsule.clastix.io"}, c.UserGroups())
+	assert.True(t, c.AllowIngressHostnameCollision())
+	assert.Equal(t, capsulev1beta1.NodePortRange{From: 30000, To: 32767}, c.NodePortRange())
+
+	spec := capsulev1beta1.CapsuleConfigurationSpec{
+		UserGroups:                     []string{"tenants"},
+		ForceTenantPrefix:              true,
+		ProtectedNamespaceRegexpString: "^kube-.*",
+		ReservedTenantNames:            []string{"default"},
+	}
+	obj := newCapsuleConfiguration(spec)
+	c.store(obj)
+
+	assert.Equal(t, []string{"tenants"}, c.UserGroups())
+	assert.True(t, c.ForceTenantPrefix())
+	assert.False(t, c.AllowIngressHostnameCollision())
+	assert.Equal(t, []string{"default"}, c.ReservedTenantNames())
+	// the Tenant name length is defaulted when missing, such as for CapsuleConfiguration created through v1alpha1
+	assert.Equal(t, validation.DNS1123LabelMaxLength, c.TenantNameMaxLength())
+	// the node port range is defaulted as well
+	assert.Equal(t, capsulev1beta1.NodePortRange{From: 30000, To: 32767}, c.NodePortRange())
+
+	re, err := c.ProtectedNamespaceRegexp()
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("kube-public"))
+
+	// the snapshot is a copy, not affected by the changes of the informer object
+	obj.Spec.UserGroups[0] = "changed"
+	assert.Equal(t, []string{"tenants"}, c.UserGroups())
+
+	t.Run("an invalid configuration is not applied", func(t *testing.T) {
+		c.store(newCapsuleConfiguration(capsulev1beta1.CapsuleConfigurationSpec{
+			UserGroups:                     []string{"others"},
+			ProtectedNamespaceRegexpString: "[",
+		}))
+
+		assert.Equal(t, []string{"tenants"}, c.UserGroups())
+
+		_, err := c.ProtectedNamespaceRegexp()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCachedConfigurationHasSynced(t *testing.T) {
+	t.Run("waiting for the informer", func(t *testing.T) {
+		c := newCachedConfiguration()
+		c.informerSynced = func() bool { return false }
+
+		assert.False(t, c.HasSynced())
+	})
+
+	t.Run("waiting for the event handler", func(t *testing.T) {
+		c := newCachedConfiguration(newCapsuleConfiguration(defaultSpec()))
+
+		assert.False(t, c.HasSynced())
+
+		c.store(newCapsuleConfiguration(defaultSpec()))
+		assert.True(t, c.HasSynced())
+	})
+
+	t.Run("missing CapsuleConfiguration", func(t *testing.T) {
+		c := newCachedConfiguration()
+
+		assert.True(t, c.HasSynced())
+	})
+
+	t.Run("invalid CapsuleConfiguration", func(t *testing.T) {
+		c := newCachedConfiguration(newCapsuleConfiguration(capsulev1beta1.CapsuleConfigurationSpec{}))
+
+		c.store(newCapsuleConfiguration(capsulev1beta1.CapsuleConfigurationSpec{}))
+		assert.True(t, c.HasSynced())
+		assert.Equal(t, []string{"capsule.clastix.io"}, c.UserGroups())
+	})
+}
@@ -5,12 +5,37 @@ package configuration
 
 import (
 	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
 
 type Configuration interface {
+	// HasSynced returns true once the CapsuleConfiguration has been loaded, or found missing.
+	HasSynced() bool
 	AllowIngressHostnameCollision() bool
 	AllowTenantIngressHostnamesCollision() bool
 	ProtectedNamespaceRegexp() (*regexp.Regexp, error)
 	ForceTenantPrefix() bool
 	UserGroups() []string
+	TenantNameMaxLength() int
+	ReservedTenantNames() []string
+	NodePortRange() capsulev1beta1.NodePortRange
+}
+
+// DefaultNodePortRange is the default value of the --service-node-port-range flag of the API Server.
+const DefaultNodePortRange = "30000-32767"
+
+// defaultSpec returns the Capsule configuration used when the CapsuleConfiguration resource is missing.
+func defaultSpec() capsulev1beta1.CapsuleConfigurationSpec {
+	return capsulev1beta1.CapsuleConfigurationSpec{
+		UserGroups:                           []string{"capsule.clastix.io"},
+		ForceTenantPrefix:                    false,
+		ProtectedNamespaceRegexpString:       "",
+		AllowTenantIngressHostnamesCollision: false,
+		AllowIngressHostnameCollision:        true,
+		TenantNameMaxLength:                  int32(validation.DNS1123LabelMaxLength),
+		NodePortRange:                        DefaultNodePortRange,
+	}
 }
@@ -0,0 +1,80 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"fmt"
+	"regexp"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// Validate returns all the errors found in the provided CapsuleConfiguration specification:
+// an invalid configuration must not be applied by Capsule.
+func Validate(spec capsulev1beta1.CapsuleConfigurationSpec) (errs field.ErrorList) {
+	specPath := field.NewPath("spec")
+
+	groupsPath := specPath.Child("userGroups")
+	if len(spec.UserGroups) == 0 {
+		errs = append(errs, field.Required(groupsPath, "at least a Capsule user group is required"))
+	}
+
+	groups := sets.NewString()
+	for i, group := range spec.UserGroups {
+		switch {
+		case len(group) == 0:
+			errs = append(errs, field.Required(groupsPath.Index(i), "group name cannot be empty"))
+		case groups.Has(group):
+			errs = append(errs, field.Duplicate(groupsPath.Index(i), group))
+		default:
+			groups.Insert(group)
+		}
+	}
+
+	if expr := spec.ProtectedNamespaceRegexpString; len(expr) > 0 {
+		if _, err := regexp.Compile(expr); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("protectedNamespaceRegex"), expr, err.Error()))
+		}
+	}
+
+	if maxLength := spec.TenantNameMaxLength; maxLength < 0 || int(maxLength) > validation.DNS1123LabelMaxLength {
+		errs = append(errs, field.Invalid(specPath.Child("tenantNameMaxLength"), maxLength, fmt.Sprintf("must be between 1 and %d, or 0 to apply the default", validation.DNS1123LabelMaxLength)))
+	}
+
+	reservedPath := specPath.Child("reservedTenantNames")
+
+	reserved := sets.NewString()
+	for i, name := range spec.ReservedTenantNames {
+		for _, msg := range validation.IsDNS1123Label(name) {
+			errs = append(errs, field.Invalid(reservedPath.Index(i), name, msg))
+		}
+		if reserved.Has(name) {
+			errs = append(errs, field.Duplicate(reservedPath.Index(i), name))
+		}
+		reserved.Insert(name)
+	}
+
+	if portRange := spec.NodePortRange; len(portRange) > 0 {
+		if _, err := parseNodePortRange(portRange); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("nodePortRange"), portRange, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// parseNodePortRange parses the node port range of the cluster, in the same format of the API Server flag.
+func parseNodePortRange(value string) (capsulev1beta1.NodePortRange, error) {
+	portRange, err := utilnet.ParsePortRange(value)
+	if err != nil {
+		return capsulev1beta1.NodePortRange{}, err
+	}
+
+	return capsulev1beta1.NodePortRange{From: int32(portRange.Base), To: int32(portRange.Base + portRange.Size - 1)}, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func(mutate func(spec *capsulev1beta1.CapsuleConfigurationSpec)) capsulev1beta1.CapsuleConfigurationSpec {
+		spec := defaultSpec()
+		mutate(&spec)
+
+		return spec
+	}
+
+	type tc struct {
+		spec     capsulev1beta1.CapsuleConfigurationSpec
+		expected []string
+	}
+
+	for name, test := range map[string]tc{
+		"defaults": {
+			spec: defaultSpec(),
+		},
+		"missing userGroups": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.UserGroups = nil
+			}),
+			expected: []string{"spec.userGroups: Required value"},
+		},
+		"empty userGroups entry": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.UserGroups = []string{"capsule.clastix.io", ""}
+			}),
+			expected: []string{"spec.userGroups[1]: Required value"},
+		},
+		"duplicated userGroups entry": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.UserGroups = []string{"capsule.clastix.io", "tenants", "capsule.clastix.io"}
+			}),
+			expected: []string{"spec.userGroups[2]: Duplicate value"},
+		},
+		"protectedNamespaceRegex": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.ProtectedNamespaceRegexpString = "^(kube|capsule)-.*$"
+			}),
+		},
+		"bad protectedNamespaceRegex": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.ProtectedNamespaceRegexpString = "^kube-(.*"
+			}),
+			expected: []string{"spec.protectedNamespaceRegex: Invalid value"},
+		},
+		"unset tenantNameMaxLength": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.TenantNameMaxLength = 0
+			}),
+		},
+		"negative tenantNameMaxLength": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.TenantNameMaxLength = -1
+			}),
+			expected: []string{"spec.tenantNameMaxLength: Invalid value"},
+		},
+		"too long tenantNameMaxLength": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.TenantNameMaxLength = 64
+			}),
+			expected: []string{"spec.tenantNameMaxLength: Invalid value"},
+		},
+		"reservedTenantNames": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.ReservedTenantNames = []string{"default", "capsule-system"}
+			}),
+		},
+		"invalid reservedTenantNames entry": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.ReservedTenantNames = []string{"default", "Capsule.System"}
+			}),
+			expected: []string{"spec.reservedTenantNames[1]: Invalid value"},
+		},
+		"duplicated reservedTenantNames entry": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.ReservedTenantNames = []string{"default", "default"}
+			}),
+			expected: []string{"spec.reservedTenantNames[1]: Duplicate value"},
+		},
+		"nodePortRange": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.NodePortRange = "20000-22767"
+			}),
+		},
+		"reversed nodePortRange": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.NodePortRange = "32767-30000"
+			}),
+			expected: []string{"spec.nodePortRange: Invalid value"},
+		},
+		"malformed nodePortRange": {
+			spec: valid(func(spec *capsulev1beta1.CapsuleConfigurationSpec) {
+				spec.NodePortRange = "a-b"
+			}),
+			expected: []string{"spec.nodePortRange: Invalid value"},
+		},
+		"multiple errors": {
+			spec: capsulev1beta1.CapsuleConfigurationSpec{
+				ProtectedNamespaceRegexpString: "[",
+				TenantNameMaxLength:            100,
+			},
+			expected: []string{
+				"spec.userGroups: Required value",
+				"spec.protectedNamespaceRegex: Invalid value",
+				"spec.tenantNameMaxLength: Invalid value",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			errs := Validate(test.spec)
+
+			assert.Len(t, errs, len(test.expected))
+			for i, expected := range test.expected {
+				if i < len(errs) {
+					assert.Contains(t, errorSummary(errs[i]), expected)
+				}
+			}
+		})
+	}
+}
+
+func errorSummary(err *field.Error) string {
+	return err.Field + ": " + err.Type.String()
+}
@@ -0,0 +1,12 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import (
+	"github.com/clastix/capsule/pkg/indexer/service"
+)
+
+func init() {
+	AddToIndexerFuncs = append(AddToIndexerFuncs, service.Type{})
+}
@@ -0,0 +1,27 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Type indexes the Services by their type.
+type Type struct {
+}
+
+func (t Type) Object() client.Object {
+	return &corev1.Service{}
+}
+
+func (t Type) Field() string {
+	return ".spec.type"
+}
+
+func (t Type) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		return []string{string(object.(*corev1.Service).Spec.Type)}
+	}
+}
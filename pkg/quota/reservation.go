@@ -0,0 +1,47 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReservationGracePeriod is the time the resources reserved upon admission could be not yet reflected
+// in the usage of the Namespace ResourceQuota objects, as observed by the Tenant controller.
+const ReservationGracePeriod = 30 * time.Second
+
+// MergeUsage returns the Tenant usage once the controller has computed the observed one: the usage reserved by the
+// admission webhook is kept when greater, for the reservations performed since less than the grace period,
+// otherwise two concurrent requests could exceed the Tenant quota.
+// The returned duration is the time after which the observed usage can be trusted, zero if it can be already.
+func MergeUsage(reserved, observed corev1.ResourceList, lastReservation *metav1.Time, now time.Time) (corev1.ResourceList, time.Duration) {
+	used := observed.DeepCopy()
+	if used == nil {
+		used = corev1.ResourceList{}
+	}
+
+	if lastReservation == nil {
+		return used, 0
+	}
+
+	remaining := lastReservation.Add(ReservationGracePeriod).Sub(now)
+	if remaining <= 0 {
+		return used, 0
+	}
+
+	for name, quantity := range reserved {
+		if !IsTracked(name) {
+			continue
+		}
+
+		if current, ok := used[name]; !ok || quantity.Cmp(current) > 0 {
+			used[name] = quantity.DeepCopy()
+		}
+	}
+
+	return used, remaining
+}
@@ -0,0 +1,221 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	storageClassSuffix = ".storageclass.storage.k8s.io/"
+
+	countPods                   corev1.ResourceName = "count/pods"
+	countPersistentVolumeClaims corev1.ResourceName = "count/persistentvolumeclaims"
+	countServices               corev1.ResourceName = "count/services"
+)
+
+// trackedResources are the resources whose usage is computed by Capsule upon admission,
+// and hence reserved atomically at Tenant level.
+var trackedResources = map[corev1.ResourceName]struct{}{
+	corev1.ResourcePods:                     {},
+	countPods:                               {},
+	corev1.ResourceCPU:                      {},
+	corev1.ResourceMemory:                   {},
+	corev1.ResourceEphemeralStorage:         {},
+	corev1.ResourceRequestsCPU:              {},
+	corev1.ResourceRequestsMemory:           {},
+	corev1.ResourceRequestsEphemeralStorage: {},
+	corev1.ResourceLimitsCPU:                {},
+	corev1.ResourceLimitsMemory:             {},
+	corev1.ResourceLimitsEphemeralStorage:   {},
+	corev1.ResourcePersistentVolumeClaims:   {},
+	countPersistentVolumeClaims:             {},
+	corev1.ResourceRequestsStorage:          {},
+	corev1.ResourceServices:                 {},
+	countServices:                           {},
+	corev1.ResourceServicesLoadBalancers:    {},
+	corev1.ResourceServicesNodePorts:        {},
+}
+
+// IsTracked returns true if the usage of the given resource is reserved by Capsule upon admission:
+// the remaining ones can only be enforced by the Namespace ResourceQuota objects.
+func IsTracked(name corev1.ResourceName) bool {
+	if _, ok := trackedResources[name]; ok {
+		return true
+	}
+	// Storage Class scoped resources, such as gold.storageclass.storage.k8s.io/requests.storage
+	return strings.Contains(name.String(), storageClassSuffix)
+}
+
+// Usage returns the resources consumed by the given object, according to the Kubernetes quota semantic.
+// Objects not handled by Capsule have no usage.
+func Usage(obj client.Object) corev1.ResourceList {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return podUsage(o)
+	case *corev1.PersistentVolumeClaim:
+		return pvcUsage(o)
+	case *corev1.Service:
+		return serviceUsage(o)
+	default:
+		return corev1.ResourceList{}
+	}
+}
+
+// NamespaceUsage returns the resources consumed by the Pods, PersistentVolumeClaims, and Services of the given Namespace:
+// as for the Kubernetes quota, the terminated Pods are not accounted.
+func NamespaceUsage(ctx context.Context, reader client.Reader, namespace string) (corev1.ResourceList, error) {
+	usage := corev1.ResourceList{}
+
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if phase := pods.Items[i].Status.Phase; phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+			continue
+		}
+		add(usage, Usage(&pods.Items[i]))
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := reader.List(ctx, pvcs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range pvcs.Items {
+		add(usage, Usage(&pvcs.Items[i]))
+	}
+
+	services := &corev1.ServiceList{}
+	if err := reader.List(ctx, services, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range services.Items {
+		add(usage, Usage(&services.Items[i]))
+	}
+
+	return usage, nil
+}
+
+// Delta returns the additional resources required moving from the old usage to the new one:
+// released resources are ignored since they're computed back by the Tenant controller.
+func Delta(newUsage, oldUsage corev1.ResourceList) corev1.ResourceList {
+	delta := corev1.ResourceList{}
+
+	for name, quantity := range newUsage {
+		q := quantity.DeepCopy()
+		if old, ok := oldUsage[name]; ok {
+			q.Sub(old)
+		}
+
+		if q.Sign() > 0 {
+			delta[name] = q
+		}
+	}
+
+	return delta
+}
+
+func podUsage(pod *corev1.Pod) corev1.ResourceList {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		add(requests, container.Resources.Requests)
+		add(limits, container.Resources.Limits)
+	}
+	// Init containers run sequentially: the effective request is the highest between each of them
+	// and the sum of the regular ones.
+	for _, container := range pod.Spec.InitContainers {
+		max(requests, container.Resources.Requests)
+		max(limits, container.Resources.Limits)
+	}
+
+	add(requests, pod.Spec.Overhead)
+	add(limits, pod.Spec.Overhead)
+
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: resource.MustParse("1"),
+		countPods:           resource.MustParse("1"),
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		if q, ok := requests[name]; ok {
+			usage[name] = q
+			usage[corev1.ResourceName("requests."+name.String())] = q
+		}
+		if q, ok := limits[name]; ok {
+			usage[corev1.ResourceName("limits."+name.String())] = q
+		}
+	}
+
+	return usage
+}
+
+func pvcUsage(pvc *corev1.PersistentVolumeClaim) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourcePersistentVolumeClaims: resource.MustParse("1"),
+		countPersistentVolumeClaims:           resource.MustParse("1"),
+	}
+
+	storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if ok {
+		usage[corev1.ResourceRequestsStorage] = storage
+	}
+
+	if pvc.Spec.StorageClassName != nil && len(*pvc.Spec.StorageClassName) > 0 {
+		prefix := *pvc.Spec.StorageClassName + storageClassSuffix
+
+		usage[corev1.ResourceName(prefix+corev1.ResourcePersistentVolumeClaims.String())] = resource.MustParse("1")
+		if ok {
+			usage[corev1.ResourceName(prefix+corev1.ResourceRequestsStorage.String())] = storage
+		}
+	}
+
+	return usage
+}
+
+func serviceUsage(svc *corev1.Service) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourceServices: resource.MustParse("1"),
+		countServices:           resource.MustParse("1"),
+	}
+
+	nodePorts := false
+
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		usage[corev1.ResourceServicesLoadBalancers] = resource.MustParse("1")
+		// the node ports of LoadBalancer Services are not allocated when disabled
+		nodePorts = svc.Spec.AllocateLoadBalancerNodePorts == nil || *svc.Spec.AllocateLoadBalancerNodePorts
+	case corev1.ServiceTypeNodePort:
+		nodePorts = true
+	}
+
+	if nodePorts && len(svc.Spec.Ports) > 0 {
+		usage[corev1.ResourceServicesNodePorts] = *resource.NewQuantity(int64(len(svc.Spec.Ports)), resource.DecimalSI)
+	}
+
+	return usage
+}
+
+func add(dst, src corev1.ResourceList) {
+	for name, quantity := range src {
+		q := dst[name]
+		q.Add(quantity)
+		dst[name] = q
+	}
+}
+
+func max(dst, src corev1.ResourceList) {
+	for name, quantity := range src {
+		if q, ok := dst[name]; !ok || quantity.Cmp(q) > 0 {
+			dst[name] = quantity.DeepCopy()
+		}
+	}
+}
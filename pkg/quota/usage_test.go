@@ -0,0 +1,264 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func container(cpu, memory string) corev1.Container {
+	return corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(cpu),
+			},
+		},
+	}
+}
+
+func TestUsage(t *testing.T) {
+	type tc struct {
+		obj      client.Object
+		expected map[corev1.ResourceName]string
+	}
+
+	for name, test := range map[string]tc{
+		"pod with containers": {
+			obj: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("100m", "64Mi"), container("200m", "64Mi")},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourcePods:           "1",
+				countPods:                     "1",
+				corev1.ResourceCPU:            "300m",
+				corev1.ResourceRequestsCPU:    "300m",
+				corev1.ResourceLimitsCPU:      "300m",
+				corev1.ResourceMemory:         "128Mi",
+				corev1.ResourceRequestsMemory: "128Mi",
+			},
+		},
+		"pod with a greedy init container": {
+			obj: &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("1", "32Mi")},
+				Containers:     []corev1.Container{container("100m", "64Mi")},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourcePods:           "1",
+				countPods:                     "1",
+				corev1.ResourceCPU:            "1",
+				corev1.ResourceRequestsCPU:    "1",
+				corev1.ResourceLimitsCPU:      "1",
+				corev1.ResourceMemory:         "64Mi",
+				corev1.ResourceRequestsMemory: "64Mi",
+			},
+		},
+		"persistent volume claim with storage class": {
+			obj: &corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("gold"),
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourcePersistentVolumeClaims:                     "1",
+				countPersistentVolumeClaims:                               "1",
+				corev1.ResourceRequestsStorage:                            "10Gi",
+				"gold.storageclass.storage.k8s.io/persistentvolumeclaims": "1",
+				"gold.storageclass.storage.k8s.io/requests.storage":       "10Gi",
+			},
+		},
+		"load balancer service": {
+			obj: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{{Port: 80}, {Port: 443}},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceServices:              "1",
+				countServices:                        "1",
+				corev1.ResourceServicesLoadBalancers: "1",
+				corev1.ResourceServicesNodePorts:     "2",
+			},
+		},
+		"load balancer service without node ports": {
+			obj: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:                          corev1.ServiceTypeLoadBalancer,
+				AllocateLoadBalancerNodePorts: pointer.BoolPtr(false),
+				Ports:                         []corev1.ServicePort{{Port: 80}, {Port: 443}},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceServices:              "1",
+				countServices:                        "1",
+				corev1.ResourceServicesLoadBalancers: "1",
+			},
+		},
+		"load balancer service explicitly allocating node ports": {
+			obj: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:                          corev1.ServiceTypeLoadBalancer,
+				AllocateLoadBalancerNodePorts: pointer.BoolPtr(true),
+				Ports:                         []corev1.ServicePort{{Port: 80}},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceServices:              "1",
+				countServices:                        "1",
+				corev1.ResourceServicesLoadBalancers: "1",
+				corev1.ResourceServicesNodePorts:     "1",
+			},
+		},
+		"node port service": {
+			obj: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{{Port: 80}, {Port: 443}, {Port: 8080}},
+			}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceServices:          "1",
+				countServices:                    "1",
+				corev1.ResourceServicesNodePorts: "3",
+			},
+		},
+		"cluster ip service": {
+			obj: &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceServices: "1",
+				countServices:           "1",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			usage := Usage(test.obj)
+
+			assert.Len(t, usage, len(test.expected))
+			for rn, expected := range test.expected {
+				q := usage[rn]
+				assert.Equal(t, 0, q.Cmp(resource.MustParse(expected)), "resource %s: expected %s, got %s", rn, expected, q.String())
+			}
+		})
+	}
+}
+
+func TestDelta(t *testing.T) {
+	oldUsage := corev1.ResourceList{
+		corev1.ResourceServices:              resource.MustParse("1"),
+		corev1.ResourceServicesNodePorts:     resource.MustParse("2"),
+		corev1.ResourceServicesLoadBalancers: resource.MustParse("1"),
+	}
+	newUsage := corev1.ResourceList{
+		corev1.ResourceServices:          resource.MustParse("1"),
+		corev1.ResourceServicesNodePorts: resource.MustParse("3"),
+	}
+
+	delta := Delta(newUsage, oldUsage)
+
+	assert.Len(t, delta, 1)
+	q := delta[corev1.ResourceServicesNodePorts]
+	assert.Equal(t, int64(1), q.Value())
+}
+
+func TestNamespaceUsage(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "oil-production", Name: name},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{container("500m", "128Mi")}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	reader := fake.NewClientBuilder().WithObjects(
+		pod("running", corev1.PodRunning),
+		pod("pending", corev1.PodPending),
+		pod("completed", corev1.PodSucceeded),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "oil-production", Name: "web"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, Ports: []corev1.ServicePort{{Port: 80}}},
+		},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "oil-development", Name: "data"}},
+	).Build()
+
+	usage, err := NamespaceUsage(context.TODO(), reader, "oil-production")
+	assert.NoError(t, err)
+
+	for name, expected := range map[corev1.ResourceName]string{
+		corev1.ResourcePods:                   "2",
+		corev1.ResourceRequestsCPU:            "1",
+		corev1.ResourceLimitsCPU:              "1",
+		corev1.ResourceRequestsMemory:         "256Mi",
+		corev1.ResourceServices:               "1",
+		corev1.ResourceServicesNodePorts:      "1",
+		corev1.ResourcePersistentVolumeClaims: "0",
+	} {
+		q := usage[name]
+		assert.Equal(t, expected, q.String(), name)
+	}
+}
+
+func TestIsTracked(t *testing.T) {
+	assert.True(t, IsTracked(corev1.ResourceRequestsCPU))
+	assert.True(t, IsTracked("gold.storageclass.storage.k8s.io/requests.storage"))
+	assert.False(t, IsTracked("count/deployments.apps"))
+	assert.False(t, IsTracked(corev1.ResourceSecrets))
+}
+
+func TestMergeUsage(t *testing.T) {
+	now := time.Now()
+
+	reserved := corev1.ResourceList{
+		corev1.ResourcePods:        resource.MustParse("3"),
+		corev1.ResourceRequestsCPU: resource.MustParse("1"),
+	}
+	observed := corev1.ResourceList{
+		corev1.ResourcePods:        resource.MustParse("2"),
+		corev1.ResourceRequestsCPU: resource.MustParse("2"),
+	}
+
+	tests := []struct {
+		name            string
+		lastReservation *metav1.Time
+		used            corev1.ResourceList
+		requeue         bool
+	}{
+		{
+			name: "without reservations",
+			used: observed,
+		},
+		{
+			name:            "reconciling after a reservation",
+			lastReservation: &metav1.Time{Time: now.Add(-time.Second)},
+			used: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("3"),
+				corev1.ResourceRequestsCPU: resource.MustParse("2"),
+			},
+			requeue: true,
+		},
+		{
+			name:            "reconciling after the grace period",
+			lastReservation: &metav1.Time{Time: now.Add(-ReservationGracePeriod)},
+			used:            observed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			used, requeue := MergeUsage(reserved, observed, tc.lastReservation, now)
+
+			assert.Equal(t, tc.requeue, requeue > 0)
+			assert.Equal(t, len(tc.used), len(used))
+			for name, quantity := range tc.used {
+				assert.Zero(t, quantity.Cmp(used[name]), name)
+			}
+		})
+	}
+}
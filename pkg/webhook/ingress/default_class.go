@@ -0,0 +1,111 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+const (
+	isDefaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+type defaultClass struct {
+}
+
+func DefaultClass() capsulewebhook.Handler {
+	return &defaultClass{}
+}
+
+func (r *defaultClass) isClusterDefault(ctx context.Context, c client.Client) func(string) (bool, error) {
+	return func(name string) (bool, error) {
+		class := &networkingv1.IngressClass{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, class); err != nil {
+			// the IngressClass resource is not served by Kubernetes prior to 1.19
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return class.GetAnnotations()[isDefaultIngressClassAnnotation] == "true", nil
+	}
+}
+
+func (r *defaultClass) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		ingress, err := ingressFromRequest(req, decoder)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		var tenant *capsulev1beta1.Tenant
+
+		tenant, err = tenantFromIngress(ctx, c, ingress)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if tenant == nil {
+			return nil
+		}
+
+		allowed := tenant.Spec.IngressClasses
+		if allowed == nil || !allowed.IsDefaulted() {
+			return nil
+		}
+
+		var ingressClass string
+		if class := ingress.IngressClass(); class != nil {
+			ingressClass = *class
+		}
+
+		omitted, err := utils.IsClassOmitted(allowed, ingressClass, r.isClusterDefault(ctx, c))
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if !omitted {
+			return nil
+		}
+
+		recorder.Eventf(tenant, corev1.EventTypeNormal, "DefaultedIngressClass", "Ingress %s/%s class has been set to %s", ingress.Namespace(), ingress.Name(), allowed.Default)
+
+		ingress.SetIngressClass(allowed.Default)
+
+		marshaled, err := json.Marshal(ingress)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+		return &response
+	}
+}
+
+func (r *defaultClass) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *defaultClass) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
@@ -17,6 +17,7 @@ const (
 
 type Ingress interface {
 	IngressClass() *string
+	SetIngressClass(name string)
 	Namespace() string
 	Name() string
 	Hostnames() []string
@@ -42,6 +43,10 @@ func (n NetworkingV1) IngressClass() (res *string) {
 	return
 }
 
+func (n NetworkingV1) SetIngressClass(name string) {
+	n.Spec.IngressClassName = &name
+}
+
 func (n NetworkingV1) Namespace() string {
 	return n.GetNamespace()
 }
@@ -75,6 +80,10 @@ func (n NetworkingV1Beta1) IngressClass() (res *string) {
 	return
 }
 
+func (n NetworkingV1Beta1) SetIngressClass(name string) {
+	n.Spec.IngressClassName = &name
+}
+
 func (n NetworkingV1Beta1) Namespace() string {
 	return n.GetNamespace()
 }
@@ -108,6 +117,10 @@ func (e Extension) IngressClass() (res *string) {
 	return
 }
 
+func (e Extension) SetIngressClass(name string) {
+	e.Spec.IngressClassName = &name
+}
+
 func (e Extension) Namespace() string {
 	return e.GetNamespace()
 }
@@ -84,7 +84,9 @@ func (r *class) OnUpdate(client client.Client, decoder *admission.Decoder, recor
 			return nil
 		}
 
-		err = r.validateClass(*tenant, ingress.IngressClass())
+		if err = r.validateClass(*tenant, ingress.IngressClass()); err == nil {
+			return nil
+		}
 
 		var forbiddenErr *ingressClassForbidden
 
@@ -116,7 +118,7 @@ func (r *class) validateClass(tenant capsulev1beta1.Tenant, ingressClass *string
 	}
 
 	if ingressClass == nil {
-		return NewIngressClassNotValid(*tenant.Spec.IngressClasses)
+		return NewIngressClassNotValid(tenant.Spec.IngressClasses.AllowedListSpec)
 	}
 
 	var valid, matched bool
@@ -127,7 +129,7 @@ func (r *class) validateClass(tenant capsulev1beta1.Tenant, ingressClass *string
 	matched = tenant.Spec.IngressClasses.RegexMatch(*ingressClass)
 
 	if !valid && !matched {
-		return NewIngressClassForbidden(*ingressClass, *tenant.Spec.IngressClasses)
+		return NewIngressClassForbidden(*ingressClass, tenant.Spec.IngressClasses.AllowedListSpec)
 	}
 
 	return nil
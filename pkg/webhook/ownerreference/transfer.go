@@ -0,0 +1,233 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package ownerreference
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/configuration"
+	"github.com/clastix/capsule/pkg/quota"
+	"github.com/clastix/capsule/pkg/utils"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	webhookutils "github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type transferHandler struct {
+	cfg configuration.Configuration
+}
+
+// TransferHandler moves a Namespace to a different Tenant when a cluster administrator changes its
+// capsule.clastix.io/tenant label: the target Tenant must not be cordoned nor full, its resource quota must fit
+// the Namespace usage, and the Namespace name must match its prefix when forced, then the Tenant owner reference is replaced.
+// The resources replicated by Capsule for the previous Tenant are replaced by the Tenant controller, looking up
+// the capsule.clastix.io/transferred-from annotation.
+func TransferHandler(cfg configuration.Configuration) capsulewebhook.Handler {
+	return &transferHandler{
+		cfg: cfg,
+	}
+}
+
+func (h *transferHandler) OnCreate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *transferHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *transferHandler) OnUpdate(clt client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		oldNs, ns := &corev1.Namespace{}, &corev1.Namespace{}
+		if err := decoder.DecodeRaw(req.OldObject, oldNs); err != nil {
+			return webhookutils.ErroredResponse(err)
+		}
+		if err := decoder.Decode(req, ns); err != nil {
+			return webhookutils.ErroredResponse(err)
+		}
+
+		ln, err := capsulev1beta1.GetTypeLabel(&capsulev1beta1.Tenant{})
+		if err != nil {
+			return webhookutils.ErroredResponse(err)
+		}
+
+		target, ok := ns.GetLabels()[ln]
+		if !ok || target == oldNs.GetLabels()[ln] {
+			return nil
+		}
+
+		owner := metav1.GetControllerOf(ns)
+		if owner == nil || owner.Kind != "Tenant" || owner.Name == target {
+			return nil
+		}
+
+		if h.isCapsuleUser(req) {
+			response := admission.Denied("Only cluster administrators can transfer a Namespace to a different Tenant")
+
+			return &response
+		}
+
+		source := &capsulev1beta1.Tenant{}
+		if err = clt.Get(ctx, types.NamespacedName{Name: owner.Name}, source); err != nil && !errors.IsNotFound(err) {
+			return webhookutils.ErroredResponse(err)
+		}
+
+		tnt := &capsulev1beta1.Tenant{}
+		if err = clt.Get(ctx, types.NamespacedName{Name: target}, tnt); err != nil {
+			if errors.IsNotFound(err) {
+				response := admission.Denied(fmt.Sprintf("Namespace %s cannot be transferred to the non existing Tenant %s", ns.GetName(), target))
+
+				return &response
+			}
+
+			return webhookutils.ErroredResponse(err)
+		}
+
+		var exceeded string
+		if exceeded, err = h.exceededQuota(ctx, clt, tnt, ns); err != nil {
+			return webhookutils.ErroredResponse(err)
+		}
+
+		if response := h.validateTransfer(source, tnt, ns, exceeded, recorder); response != nil {
+			return response
+		}
+
+		return h.patchResponseForTransfer(owner, source, tnt, ns, recorder)
+	}
+}
+
+// exceededQuota returns the Tenant scoped resource quota of the target Tenant that would be exceeded by the usage
+// of the transferred Namespace, as computed upon admission: the resources not tracked by Capsule are ignored.
+func (h *transferHandler) exceededQuota(ctx context.Context, clt client.Client, tnt *capsulev1beta1.Tenant, ns *corev1.Namespace) (string, error) {
+	if tnt.Spec.ResourceQuota == nil {
+		return "", nil
+	}
+
+	usage, err := quota.NamespaceUsage(ctx, clt, ns.GetName())
+	if err != nil {
+		return "", err
+	}
+
+	for index, item := range tnt.Spec.ResourceQuota.Items {
+		if !item.IsTenantScoped() {
+			continue
+		}
+
+		var used corev1.ResourceList
+		if status := tnt.Status.GetResourceQuotaStatus(index); status != nil {
+			used = status.Used
+		}
+
+		for name, hard := range item.Hard {
+			requested, ok := usage[name]
+			if !ok || !quota.IsTracked(name) {
+				continue
+			}
+
+			current := used[name]
+
+			total := current.DeepCopy()
+			total.Add(requested)
+			if total.Cmp(hard) > 0 {
+				return fmt.Sprintf("the resource quota of the target Tenant %s would be exceeded for %s: requested %s, used %s, limited to %s", tnt.GetName(), name, requested.String(), current.String(), hard.String()), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// validateTransfer runs the same checks of the Namespace creation against the target Tenant.
+func (h *transferHandler) validateTransfer(source, tnt *capsulev1beta1.Tenant, ns *corev1.Namespace, exceeded string, recorder record.EventRecorder) *admission.Response {
+	var reason string
+
+	switch {
+	case source.IsCordoned():
+		reason = fmt.Sprintf("the current Tenant %s is freezed", source.GetName())
+	case tnt.IsCordoned():
+		reason = fmt.Sprintf("the target Tenant %s is freezed", tnt.GetName())
+	case tnt.IsFull():
+		reason = fmt.Sprintf("the Namespace quota of the target Tenant %s has been reached", tnt.GetName())
+	case len(exceeded) > 0:
+		reason = exceeded
+	case h.cfg.ForceTenantPrefix() && !strings.HasPrefix(ns.GetName(), fmt.Sprintf("%s-", tnt.GetName())):
+		reason = fmt.Sprintf("the Namespace doesn't match the target Tenant prefix %s-", tnt.GetName())
+	default:
+		return nil
+	}
+
+	recorder.Eventf(tnt, corev1.EventTypeWarning, "NamespaceTransferDenied", "Namespace %s cannot be transferred: %s", ns.GetName(), reason)
+
+	response := admission.Denied(fmt.Sprintf("Namespace %s cannot be transferred: %s", ns.GetName(), reason))
+
+	return &response
+}
+
+func (h *transferHandler) patchResponseForTransfer(owner *metav1.OwnerReference, source, tnt *capsulev1beta1.Tenant, ns *corev1.Namespace, recorder record.EventRecorder) *admission.Response {
+	scheme := runtime.NewScheme()
+	_ = capsulev1beta1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	o, _ := json.Marshal(ns.DeepCopy())
+
+	refs := make([]metav1.OwnerReference, 0, len(ns.GetOwnerReferences()))
+	for _, ref := range ns.GetOwnerReferences() {
+		if ref.UID != owner.UID {
+			refs = append(refs, ref)
+		}
+	}
+	ns.SetOwnerReferences(refs)
+
+	annotations := ns.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[capsulev1beta1.TransferredNamespaceAnnotation] = owner.Name
+	ns.SetAnnotations(annotations)
+
+	if err := controllerutil.SetControllerReference(tnt, ns, scheme); err != nil {
+		response := admission.Errored(http.StatusInternalServerError, err)
+
+		return &response
+	}
+
+	recorder.Eventf(tnt, corev1.EventTypeNormal, "NamespaceTransferred", "Namespace %s has been transferred from the Tenant %s", ns.GetName(), owner.Name)
+	if len(source.GetName()) > 0 {
+		recorder.Eventf(source, corev1.EventTypeNormal, "NamespaceTransferred", "Namespace %s has been transferred to the Tenant %s", ns.GetName(), tnt.GetName())
+	}
+
+	c, _ := json.Marshal(ns)
+	response := admission.PatchResponseFromRaw(o, c)
+
+	return &response
+}
+
+func (h *transferHandler) isCapsuleUser(req admission.Request) bool {
+	groupList := utils.NewUserGroupList(req.UserInfo.Groups)
+	for _, group := range h.cfg.UserGroups() {
+		if groupList.Find(group) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type containerImagesHandler struct {
+}
+
+func ContainerImages() capsulewebhook.Handler {
+	return &containerImagesHandler{}
+}
+
+// isAllowed checks the image reference against the Tenant policy: a digest is pinning the image
+// regardless of the tag, thus the latest or missing tag is allowed along with it.
+func (h *containerImagesHandler) isAllowed(spec capsulev1beta1.ContainerImagesSpec, reference Registry) bool {
+	if len(reference.Digest()) > 0 {
+		return true
+	}
+
+	if spec.RequireDigest {
+		return false
+	}
+
+	if spec.ForbidLatestTag && (len(reference.Tag()) == 0 || reference.Tag() == defaultTag) {
+		return false
+	}
+
+	return true
+}
+
+func (h *containerImagesHandler) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder, containers []container) *admission.Response {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+
+	if tnt.Spec.ContainerImages == nil {
+		return nil
+	}
+
+	for _, container := range containers {
+		reference, err := NewRegistry(container.image)
+		if err != nil {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "InvalidContainerImage", "%s %s/%s container %s is using the invalid image reference %s", req.Kind.Kind, req.Namespace, req.Name, container.name, container.image)
+
+			response := admission.Denied(NewContainerImageInvalid(container.image, err).Error())
+
+			return &response
+		}
+
+		if !h.isAllowed(*tnt.Spec.ContainerImages, reference) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerImage", "%s %s/%s container %s is using the image %s, forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, container.name, reference.String())
+
+			response := admission.Denied(NewContainerImageForbidden(container.image, *tnt.Spec.ContainerImages).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
+
+func (h *containerImagesHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		containers, err := decodeContainers(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.validate(ctx, c, req, recorder, containers)
+	}
+}
+
+func (h *containerImagesHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *containerImagesHandler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		containers, err := changedContainers(decoder, req)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.validate(ctx, c, req, recorder, containers)
+	}
+}
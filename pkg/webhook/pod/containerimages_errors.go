@@ -0,0 +1,36 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"fmt"
+	"strings"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+type containerImageForbidden struct {
+	fqci string
+	spec capsulev1beta1.ContainerImagesSpec
+}
+
+func NewContainerImageForbidden(image string, spec capsulev1beta1.ContainerImagesSpec) error {
+	return &containerImageForbidden{
+		fqci: image,
+		spec: spec,
+	}
+}
+
+func (f containerImageForbidden) Error() (err string) {
+	err = fmt.Sprintf("Container image %s reference is forbidden for the current Tenant: ", f.fqci)
+	var extra []string
+	if f.spec.RequireDigest {
+		extra = append(extra, "the image must be pinned by digest")
+	}
+	if f.spec.ForbidLatestTag {
+		extra = append(extra, "the image must specify a tag other than latest, or be pinned by digest")
+	}
+	err += strings.Join(extra, " and ")
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestContainerImagesIsAllowed(t *testing.T) {
+	digest := "@sha256:" + "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	requireDigest := capsulev1beta1.ContainerImagesSpec{RequireDigest: true}
+	forbidLatest := capsulev1beta1.ContainerImagesSpec{ForbidLatestTag: true}
+
+	tests := []struct {
+		name    string
+		spec    capsulev1beta1.ContainerImagesSpec
+		image   string
+		allowed bool
+	}{
+		{name: "no policy", spec: capsulev1beta1.ContainerImagesSpec{}, image: "nginx", allowed: true},
+		{name: "digest required, pinned", spec: requireDigest, image: "nginx" + digest, allowed: true},
+		{name: "digest required, tagged and pinned", spec: requireDigest, image: "nginx:1.21" + digest, allowed: true},
+		{name: "digest required, tagged", spec: requireDigest, image: "nginx:1.21", allowed: false},
+		{name: "digest required, untagged", spec: requireDigest, image: "nginx", allowed: false},
+		{name: "latest forbidden, tagged", spec: forbidLatest, image: "nginx:1.21", allowed: true},
+		{name: "latest forbidden, latest", spec: forbidLatest, image: "nginx:latest", allowed: false},
+		{name: "latest forbidden, untagged", spec: forbidLatest, image: "localhost:5000/app", allowed: false},
+		{name: "latest forbidden, pinned", spec: forbidLatest, image: "nginx" + digest, allowed: true},
+		{name: "latest forbidden, latest and pinned", spec: forbidLatest, image: "nginx:latest" + digest, allowed: true},
+	}
+
+	h := &containerImagesHandler{}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reference, err := NewRegistry(tc.image)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.allowed, h.isAllowed(tc.spec, reference))
+		})
+	}
+}
+
+func TestDefaultPullPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowed   []capsulev1beta1.ImagePullPolicySpec
+		defaulted capsulev1beta1.ImagePullPolicySpec
+		policy    string
+		expected  string
+	}{
+		{name: "no default", allowed: []capsulev1beta1.ImagePullPolicySpec{"Always"}, policy: "IfNotPresent", expected: "IfNotPresent"},
+		{name: "unset", defaulted: "Always", policy: "", expected: "Always"},
+		{name: "set without enforcement", defaulted: "Always", policy: "Never", expected: "Never"},
+		{name: "allowed", allowed: []capsulev1beta1.ImagePullPolicySpec{"Always", "Never"}, defaulted: "Always", policy: "Never", expected: "Never"},
+		{name: "not allowed", allowed: []capsulev1beta1.ImagePullPolicySpec{"Always"}, defaulted: "Always", policy: "IfNotPresent", expected: "Always"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tnt := &capsulev1beta1.Tenant{
+				Spec: capsulev1beta1.TenantSpec{
+					ImagePullPolicies: tc.allowed,
+					ContainerImages: &capsulev1beta1.ContainerImagesSpec{
+						DefaultPullPolicy: tc.defaulted,
+					},
+				},
+			}
+
+			assert.Equal(t, tc.expected, defaultPullPolicy(tnt, tc.policy))
+		})
+	}
+}
@@ -24,47 +24,75 @@ func ContainerRegistry() capsulewebhook.Handler {
 	return &containerRegistryHandler{}
 }
 
-func (h *containerRegistryHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
-	return func(ctx context.Context, req admission.Request) *admission.Response {
-		pod := &corev1.Pod{}
-		if err := decoder.Decode(req, pod); err != nil {
-			return utils.ErroredResponse(err)
+// isAllowed matches the allowed entries against the registry, the repository prefixes, and the full reference,
+// while the regular expression is matched against the registry only.
+func (h *containerRegistryHandler) isAllowed(spec capsulev1beta1.AllowedListSpec, reference Registry) bool {
+	for _, candidate := range reference.Candidates() {
+		if spec.ExactMatch(candidate) {
+			return true
 		}
+	}
 
-		tntList := &capsulev1beta1.TenantList{}
-		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector(".status.namespaces", pod.Namespace),
-		}); err != nil {
-			return utils.ErroredResponse(err)
-		}
+	return spec.RegexMatch(reference.Registry())
+}
 
-		if len(tntList.Items) == 0 {
-			return nil
-		}
+func (h *containerRegistryHandler) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder, containers []container) *admission.Response {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
 
-		tnt := tntList.Items[0]
+	tnt := tntList.Items[0]
 
-		if tnt.Spec.ContainerRegistries != nil {
-			var valid, matched bool
+	if tnt.Spec.ContainerRegistries != nil {
+		for _, container := range containers {
+			// the registry rewrites have been already applied to the Pods, while the Pod templates
+			// are rewritten only upon the Pod creation, thus the rewritten image is validated
+			image := container.image
+			if !isPod(req) {
+				image = rewriteImage(tnt.Spec.ContainerImages, image)
+			}
 
-			for _, container := range pod.Spec.Containers {
-				registry := NewRegistry(container.Image)
+			reference, err := NewRegistry(image)
+			if err != nil {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "InvalidContainerImage", "%s %s/%s container %s is using the invalid image reference %s", req.Kind.Kind, req.Namespace, req.Name, container.name, container.image)
 
-				valid = tnt.Spec.ContainerRegistries.ExactMatch(registry.Registry())
+				response := admission.Denied(NewContainerImageInvalid(container.image, err).Error())
 
-				matched = tnt.Spec.ContainerRegistries.RegexMatch(registry.Registry())
+				return &response
+			}
 
-				if !valid && !matched {
-					recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "Pod %s/%s is using a forbidden registry %s is forbidden for the current Tenant", req.Namespace, req.Name, registry.Registry())
+			if !h.isAllowed(*tnt.Spec.ContainerRegistries, reference) {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenContainerRegistry", "%s %s/%s container %s is using the registry %s, forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, container.name, reference.Registry())
 
-					response := admission.Denied(NewContainerRegistryForbidden(container.Image, *tnt.Spec.ContainerRegistries).Error())
+				response := admission.Denied(NewContainerRegistryForbidden(reference.String(), *tnt.Spec.ContainerRegistries).Error())
 
-					return &response
-				}
+				return &response
 			}
 		}
+	}
 
-		return nil
+	return nil
+}
+
+func (h *containerRegistryHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		containers, err := decodeContainers(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.validate(ctx, c, req, recorder, containers)
 	}
 }
 
@@ -74,8 +102,13 @@ func (h *containerRegistryHandler) OnDelete(client.Client, *admission.Decoder, r
 	}
 }
 
-func (h *containerRegistryHandler) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+func (h *containerRegistryHandler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		return nil
+		containers, err := changedContainers(decoder, req)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.validate(ctx, c, req, recorder, containers)
 	}
 }
@@ -35,3 +35,19 @@ func (f registryClassForbidden) Error() (err string) {
 	err += strings.Join(extra, " or ")
 	return
 }
+
+type containerImageInvalid struct {
+	image string
+	err   error
+}
+
+func NewContainerImageInvalid(image string, err error) error {
+	return &containerImageInvalid{
+		image: image,
+		err:   err,
+	}
+}
+
+func (f containerImageInvalid) Error() string {
+	return fmt.Sprintf("Container image %s is not a valid reference: %s", f.image, f.err.Error())
+}
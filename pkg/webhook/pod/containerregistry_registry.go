@@ -4,69 +4,222 @@
 package pod
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 )
 
-const defaultRegistryName = "docker.io"
+const (
+	defaultRegistryName   = "docker.io"
+	legacyRegistryName    = "index.docker.io"
+	officialRepoNamespace = "library"
+	defaultTag            = "latest"
+	nameTotalLengthMax    = 255
+)
 
-type registry map[string]string
+var (
+	ErrReferenceInvalidFormat       = errors.New("invalid reference format")
+	ErrReferenceNameEmpty           = errors.New("repository name must have at least one component")
+	ErrReferenceNameContainsUpper   = errors.New("repository name must be lowercase")
+	ErrReferenceNameTooLong         = fmt.Errorf("repository name must not be more than %d characters", nameTotalLengthMax)
+	ErrReferenceDigestInvalidLength = errors.New("invalid checksum digest length")
+	ErrReferenceDigestUnsupported   = errors.New("unsupported digest algorithm")
+)
 
-func (r registry) Registry() string {
-	res, ok := r["registry"]
-	if !ok {
-		return ""
-	}
-	if len(res) == 0 {
-		return defaultRegistryName
+// The grammar follows the one of the OCI distribution specification, as implemented by the docker CLI.
+var (
+	alphaNumeric    = `[a-z0-9]+`
+	separator       = `(?:[._]|__|[-]*)`
+	nameComponent   = alphaNumeric + `(?:` + separator + alphaNumeric + `)*`
+	domainComponent = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	domain          = `(?:` + domainComponent + `(?:\.` + domainComponent + `)*|\[[a-fA-F0-9:]+\])(?::[0-9]+)?`
+	tag             = `[\w][\w.-]{0,127}`
+	digest          = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+	name            = `(?:` + domain + `/)?` + nameComponent + `(?:/` + nameComponent + `)*`
+
+	referenceRegexp  = regexp.MustCompile(`^(` + name + `)(?::(` + tag + `))?(?:@(` + digest + `))?$`)
+	anchoredIDRegexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+	digestLengths = map[string]int{
+		"sha256": 64,
+		"sha384": 96,
+		"sha512": 128,
 	}
-	return res
+)
+
+type registry struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// Registry returns the registry hosting the image, e.g. docker.io or localhost:5000.
+func (r registry) Registry() string {
+	return r.registry
 }
 
+// Repository returns the path of the image in the registry, e.g. library/nginx.
 func (r registry) Repository() string {
-	res, ok := r["repository"]
-	if !ok {
-		return ""
+	return r.repository
+}
+
+// Image returns the last component of the repository, e.g. nginx.
+func (r registry) Image() string {
+	return r.repository[strings.LastIndex(r.repository, "/")+1:]
+}
+
+// Name returns the fully qualified name of the image, registry and repository included.
+func (r registry) Name() string {
+	return r.registry + "/" + r.repository
+}
+
+// Tag returns the tag of the image, empty if not specified.
+func (r registry) Tag() string {
+	return r.tag
+}
+
+// Digest returns the digest of the image, empty if not specified.
+func (r registry) Digest() string {
+	return r.digest
+}
+
+// String returns the normalized reference: as the docker CLI does, the latest tag is implied
+// when neither the tag nor the digest are specified.
+func (r registry) String() string {
+	ref := r.Name()
+	if len(r.tag) == 0 && len(r.digest) == 0 {
+		return ref + ":" + defaultTag
+	}
+	if len(r.tag) > 0 {
+		ref += ":" + r.tag
 	}
-	if res == defaultRegistryName {
-		return ""
+	if len(r.digest) > 0 {
+		ref += "@" + r.digest
 	}
-	return res
+	return ref
 }
 
-func (r registry) Image() string {
-	res, ok := r["image"]
-	if !ok {
-		return ""
+// Candidates returns the values an allowed list entry can be compared to: the registry, each repository
+// prefix on a path component boundary, and the full reference, with and without the tag or the digest.
+func (r registry) Candidates() (candidates []string) {
+	candidates = append(candidates, r.registry)
+
+	prefix := r.registry
+	for _, component := range strings.Split(r.repository, "/") {
+		prefix += "/" + component
+		candidates = append(candidates, prefix)
+	}
+
+	if len(r.tag) > 0 {
+		candidates = append(candidates, r.Name()+":"+r.tag)
+	}
+	if len(r.digest) > 0 {
+		candidates = append(candidates, r.Name()+"@"+r.digest)
 	}
-	return res
+
+	return append(candidates, r.String())
 }
 
-func (r registry) Tag() string {
-	res, ok := r["tag"]
-	if !ok {
-		return ""
+// Rewrite replaces the longest registry, or repository prefix on a path component boundary, matching a key
+// of the given map with its value: the tag and the digest are kept, the latest tag is not implied.
+func (r registry) Rewrite(rewrites map[string]string) (string, bool) {
+	name := r.Name()
+
+	for prefix := name; len(prefix) > 0; {
+		if target, ok := rewrites[prefix]; ok {
+			image := strings.TrimSuffix(target, "/") + name[len(prefix):]
+			if len(r.tag) > 0 {
+				image += ":" + r.tag
+			}
+			if len(r.digest) > 0 {
+				image += "@" + r.digest
+			}
+			return image, true
+		}
+
+		i := strings.LastIndex(prefix, "/")
+		if i == -1 {
+			break
+		}
+		prefix = prefix[:i]
 	}
-	if len(res) == 0 {
-		res = "latest"
+
+	return "", false
+}
+
+// splitRegistry splits the registry from the remainder of the reference, the former is detected as the
+// docker CLI does: the first component must contain a dot or a port, be localhost, or contain an uppercase letter.
+func splitRegistry(value string) (string, string) {
+	i := strings.IndexRune(value, '/')
+	if i == -1 || (!strings.ContainsAny(value[:i], ".:") && value[:i] != "localhost" && strings.ToLower(value[:i]) == value[:i]) {
+		return defaultRegistryName, value
 	}
-	return res
+
+	return value[:i], value[i+1:]
 }
 
-func NewRegistry(value string) Registry {
-	reg := make(registry)
-	r := regexp.MustCompile(`(((?P<registry>[a-zA-Z0-9-.]+)\/)?((?P<repository>[a-zA-Z0-9-.]+)\/))?(?P<image>[a-zA-Z0-9-.]+)(:(?P<tag>[a-zA-Z0-9-.]+))?`)
-	match := r.FindStringSubmatch(value)
-	for i, name := range r.SubexpNames() {
-		if i > 0 && i <= len(match) {
-			reg[name] = match[i]
+func NewRegistry(value string) (Registry, error) {
+	if len(value) == 0 {
+		return nil, ErrReferenceNameEmpty
+	}
+	if anchoredIDRegexp.MatchString(value) {
+		return nil, fmt.Errorf("invalid repository name (%s), cannot specify 64-byte hexadecimal strings", value)
+	}
+
+	host, remainder := splitRegistry(value)
+	if host == legacyRegistryName {
+		host = defaultRegistryName
+	}
+	if host == defaultRegistryName && !strings.ContainsRune(remainder, '/') {
+		remainder = officialRepoNamespace + "/" + remainder
+	}
+
+	remoteName := remainder
+	if i := strings.IndexAny(remoteName, ":@"); i > -1 {
+		remoteName = remoteName[:i]
+	}
+	if strings.ToLower(remoteName) != remoteName {
+		return nil, ErrReferenceNameContainsUpper
+	}
+
+	match := referenceRegexp.FindStringSubmatch(host + "/" + remainder)
+	if match == nil {
+		return nil, ErrReferenceInvalidFormat
+	}
+
+	if len(match[1]) > nameTotalLengthMax {
+		return nil, ErrReferenceNameTooLong
+	}
+
+	if d := match[3]; len(d) > 0 {
+		i := strings.IndexRune(d, ':')
+		length, ok := digestLengths[d[:i]]
+		if !ok {
+			return nil, ErrReferenceDigestUnsupported
+		}
+		if len(d[i+1:]) != length {
+			return nil, ErrReferenceDigestInvalidLength
 		}
 	}
-	return reg
+
+	return registry{
+		registry:   host,
+		repository: remoteName,
+		tag:        match[2],
+		digest:     match[3],
+	}, nil
 }
 
 type Registry interface {
 	Registry() string
 	Repository() string
 	Image() string
+	Name() string
 	Tag() string
+	Digest() string
+	String() string
+	Candidates() []string
+	Rewrite(rewrites map[string]string) (string, bool)
 }
@@ -0,0 +1,287 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestNewRegistry(t *testing.T) {
+	sha256 := "sha256:" + "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	tests := []struct {
+		image      string
+		registry   string
+		repository string
+		component  string
+		tag        string
+		digest     string
+		reference  string
+		err        error
+	}{
+		{
+			image:      "nginx",
+			registry:   "docker.io",
+			repository: "library/nginx",
+			component:  "nginx",
+			reference:  "docker.io/library/nginx:latest",
+		},
+		{
+			image:      "nginx:1.21",
+			registry:   "docker.io",
+			repository: "library/nginx",
+			component:  "nginx",
+			tag:        "1.21",
+			reference:  "docker.io/library/nginx:1.21",
+		},
+		{
+			image:      "bitnami/nginx:1.21",
+			registry:   "docker.io",
+			repository: "bitnami/nginx",
+			component:  "nginx",
+			tag:        "1.21",
+			reference:  "docker.io/bitnami/nginx:1.21",
+		},
+		{
+			image:      "index.docker.io/nginx",
+			registry:   "docker.io",
+			repository: "library/nginx",
+			component:  "nginx",
+			reference:  "docker.io/library/nginx:latest",
+		},
+		{
+			image:      "localhost:5000/app",
+			registry:   "localhost:5000",
+			repository: "app",
+			component:  "app",
+			reference:  "localhost:5000/app:latest",
+		},
+		{
+			image:      "localhost/app:v1",
+			registry:   "localhost",
+			repository: "app",
+			component:  "app",
+			tag:        "v1",
+			reference:  "localhost/app:v1",
+		},
+		{
+			image:      "registry.internal.tld:8443/team/app:v1.0.0-rc.1",
+			registry:   "registry.internal.tld:8443",
+			repository: "team/app",
+			component:  "app",
+			tag:        "v1.0.0-rc.1",
+			reference:  "registry.internal.tld:8443/team/app:v1.0.0-rc.1",
+		},
+		{
+			image:      "ghcr.io/org/team/app",
+			registry:   "ghcr.io",
+			repository: "org/team/app",
+			component:  "app",
+			reference:  "ghcr.io/org/team/app:latest",
+		},
+		{
+			image:      "quay.io/org/app@" + sha256,
+			registry:   "quay.io",
+			repository: "org/app",
+			component:  "app",
+			digest:     sha256,
+			reference:  "quay.io/org/app@" + sha256,
+		},
+		{
+			image:      "quay.io/org/app:v1@" + sha256,
+			registry:   "quay.io",
+			repository: "org/app",
+			component:  "app",
+			tag:        "v1",
+			digest:     sha256,
+			reference:  "quay.io/org/app:v1@" + sha256,
+		},
+		{
+			image:      "Registry/app",
+			registry:   "Registry",
+			repository: "app",
+			component:  "app",
+			reference:  "Registry/app:latest",
+		},
+		{
+			image:      "[::1]:5000/app",
+			registry:   "[::1]:5000",
+			repository: "app",
+			component:  "app",
+			reference:  "[::1]:5000/app:latest",
+		},
+		{
+			image:      "my_registry/my__app",
+			registry:   "docker.io",
+			repository: "my_registry/my__app",
+			component:  "my__app",
+			reference:  "docker.io/my_registry/my__app:latest",
+		},
+		{image: "", err: ErrReferenceNameEmpty},
+		{image: "docker.io/Nginx", err: ErrReferenceNameContainsUpper},
+		{image: "ghcr.io/Org/app", err: ErrReferenceNameContainsUpper},
+		{image: "nginx:", err: ErrReferenceInvalidFormat},
+		{image: "nginx::latest", err: ErrReferenceInvalidFormat},
+		{image: "ghcr.io//app", err: ErrReferenceInvalidFormat},
+		{image: "-nginx", err: ErrReferenceInvalidFormat},
+		{image: "nginx:-latest", err: ErrReferenceInvalidFormat},
+		{image: "nginx@sha256:abc", err: ErrReferenceInvalidFormat},
+		{image: "nginx@sha256:" + "0123456789abcdef0123456789abcdef", err: ErrReferenceDigestInvalidLength},
+		{image: "nginx@md5:" + "0123456789abcdef0123456789abcdef", err: ErrReferenceDigestUnsupported},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			reference, err := NewRegistry(tc.image)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.registry, reference.Registry())
+			assert.Equal(t, tc.repository, reference.Repository())
+			assert.Equal(t, tc.component, reference.Image())
+			assert.Equal(t, tc.tag, reference.Tag())
+			assert.Equal(t, tc.digest, reference.Digest())
+			assert.Equal(t, tc.reference, reference.String())
+		})
+	}
+
+	t.Run("hexadecimal identifier", func(t *testing.T) {
+		_, err := NewRegistry("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+		assert.Error(t, err)
+	})
+}
+
+func TestContainerRegistryIsAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    capsulev1beta1.AllowedListSpec
+		image   string
+		allowed bool
+	}{
+		{
+			name:    "registry",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io"}},
+			image:   "nginx",
+			allowed: true,
+		},
+		{
+			name:    "registry with port",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"localhost:5000"}},
+			image:   "localhost:5000/app",
+			allowed: true,
+		},
+		{
+			name:    "registry with a different port",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"localhost:5000"}},
+			image:   "localhost:5001/app",
+			allowed: false,
+		},
+		{
+			name:    "repository prefix",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"ghcr.io/org"}},
+			image:   "ghcr.io/org/team/app:v1",
+			allowed: true,
+		},
+		{
+			name:    "repository prefix on a component boundary",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"ghcr.io/org"}},
+			image:   "ghcr.io/organization/app:v1",
+			allowed: false,
+		},
+		{
+			name:    "normalized official image",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io/library/nginx"}},
+			image:   "nginx:1.21",
+			allowed: true,
+		},
+		{
+			name:    "full reference",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io/library/nginx:1.21"}},
+			image:   "nginx:1.21",
+			allowed: true,
+		},
+		{
+			name:    "full reference with implied tag",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io/library/nginx:latest"}},
+			image:   "nginx",
+			allowed: true,
+		},
+		{
+			name:    "full reference with a different tag",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io/library/nginx:1.21"}},
+			image:   "nginx:1.20",
+			allowed: false,
+		},
+		{
+			name:    "registry regex",
+			spec:    capsulev1beta1.AllowedListSpec{Regex: `^quay\.\w+$`},
+			image:   "quay.io/org/app",
+			allowed: true,
+		},
+		{
+			name:    "registry regex not matching the repository",
+			spec:    capsulev1beta1.AllowedListSpec{Regex: `^quay\.\w+$`},
+			image:   "docker.io/quay.io/app",
+			allowed: false,
+		},
+		{
+			name:    "forbidden registry",
+			spec:    capsulev1beta1.AllowedListSpec{Exact: []string{"docker.io"}, Regex: `^quay\.\w+$`},
+			image:   "gcr.io/google_containers/pause-amd64:3.0",
+			allowed: false,
+		},
+	}
+
+	h := &containerRegistryHandler{}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reference, err := NewRegistry(tc.image)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.allowed, h.isAllowed(tc.spec, reference))
+		})
+	}
+}
+
+func TestRegistryRewrite(t *testing.T) {
+	digest := "sha256:" + "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	rewrites := map[string]string{
+		"docker.io":         "registry.internal/dockerhub-mirror",
+		"docker.io/bitnami": "registry.internal/bitnami/",
+		"ghcr.io/org":       "registry.internal/org",
+	}
+
+	tests := []struct {
+		image     string
+		rewritten string
+		ok        bool
+	}{
+		{image: "nginx", rewritten: "registry.internal/dockerhub-mirror/library/nginx", ok: true},
+		{image: "nginx:1.21", rewritten: "registry.internal/dockerhub-mirror/library/nginx:1.21", ok: true},
+		{image: "docker.io/nginx@" + digest, rewritten: "registry.internal/dockerhub-mirror/library/nginx@" + digest, ok: true},
+		{image: "bitnami/nginx:1.21", rewritten: "registry.internal/bitnami/nginx:1.21", ok: true},
+		{image: "ghcr.io/org/team/app:v1", rewritten: "registry.internal/org/team/app:v1", ok: true},
+		{image: "ghcr.io/organization/app:v1", ok: false},
+		{image: "quay.io/org/app", ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			reference, err := NewRegistry(tc.image)
+			assert.NoError(t, err)
+
+			rewritten, ok := reference.Rewrite(rewrites)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.rewritten, rewritten)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// container describes a regular, init, or ephemeral container of a Pod or of a Pod template.
+type container struct {
+	name       string
+	image      string
+	pullPolicy corev1.PullPolicy
+}
+
+func podContainers(spec corev1.PodSpec) (containers []container) {
+	for _, c := range spec.InitContainers {
+		containers = append(containers, container{name: c.Name, image: c.Image, pullPolicy: c.ImagePullPolicy})
+	}
+	for _, c := range spec.Containers {
+		containers = append(containers, container{name: c.Name, image: c.Image, pullPolicy: c.ImagePullPolicy})
+	}
+	for _, c := range spec.EphemeralContainers {
+		containers = append(containers, container{name: c.Name, image: c.Image, pullPolicy: c.ImagePullPolicy})
+	}
+
+	return
+}
+
+// isPod returns true when the request carries a Pod, or its ephemeral containers, rather than a Pod template:
+// the mutating handlers have already run for Pods, while Pod templates are mutated only when their Pods are created.
+func isPod(req admission.Request) bool {
+	return req.Kind.Kind == "Pod" || req.Kind.Kind == "EphemeralContainers"
+}
+
+// decodePodSpec returns the Pod specification carried by the request: ephemeral containers are added
+// with the ephemeralcontainers subresource, that the API Server serves as an EphemeralContainers object
+// prior to Kubernetes 1.22, while workload controllers are carrying it in their Pod template.
+func decodePodSpec(decoder *admission.Decoder, req admission.Request, raw runtime.RawExtension) (*corev1.PodSpec, error) {
+	switch req.Kind.Kind {
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err := decoder.DecodeRaw(raw, pod); err != nil {
+			return nil, err
+		}
+
+		return &pod.Spec, nil
+	case "EphemeralContainers":
+		ec := &corev1.EphemeralContainers{}
+		if err := decoder.DecodeRaw(raw, ec); err != nil {
+			return nil, err
+		}
+
+		return &corev1.PodSpec{EphemeralContainers: ec.EphemeralContainers}, nil
+	default:
+		return decodePodTemplateSpec(decoder, req.Kind.Kind, raw)
+	}
+}
+
+// decodePodTemplateSpec extracts the Pod template of a workload controller: the object is decoded as
+// unstructured since the served API versions, especially for CronJob, are depending on the cluster version.
+func decodePodTemplateSpec(decoder *admission.Decoder, kind string, raw runtime.RawExtension) (*corev1.PodSpec, error) {
+	obj := &unstructured.Unstructured{}
+	if err := decoder.DecodeRaw(raw, obj); err != nil {
+		return nil, err
+	}
+
+	path := []string{"spec", "template"}
+	if kind == "CronJob" {
+		path = []string{"spec", "jobTemplate", "spec", "template"}
+	}
+
+	template, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s %s/%s has no Pod template", kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	tpl := &corev1.PodTemplateSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, tpl); err != nil {
+		return nil, err
+	}
+
+	return &tpl.Spec, nil
+}
+
+// decodeContainers returns all the containers of the Pod, or of the Pod template, in the request.
+func decodeContainers(decoder *admission.Decoder, req admission.Request, raw runtime.RawExtension) ([]container, error) {
+	spec, err := decodePodSpec(decoder, req, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return podContainers(*spec), nil
+}
+
+// changedContainers returns the containers added or changed by an update request: the unchanged ones
+// have been already validated, and must not be rejected due to a later change of the Tenant policies.
+func changedContainers(decoder *admission.Decoder, req admission.Request) ([]container, error) {
+	newContainers, err := decodeContainers(decoder, req, req.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	oldContainers, err := decodeContainers(decoder, req, req.OldObject)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]container, len(oldContainers))
+	for _, c := range oldContainers {
+		existing[c.name] = c
+	}
+
+	var changed []container
+	for _, c := range newContainers {
+		if old, ok := existing[c.name]; ok && old == c {
+			continue
+		}
+		changed = append(changed, c)
+	}
+
+	return changed, nil
+}
+
+// mutableContainers returns the names of the containers the mutating handlers are allowed to change: all of them
+// upon creation, only the added or changed ones upon update, since the running containers must be left untouched.
+func mutableContainers(decoder *admission.Decoder, req admission.Request) (func(name string) bool, error) {
+	if req.OldObject.Raw == nil {
+		return func(string) bool { return true }, nil
+	}
+
+	changed, err := changedContainers(decoder, req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(changed))
+	for _, c := range changed {
+		names[c.name] = struct{}{}
+	}
+
+	return func(name string) bool {
+		_, ok := names[name]
+
+		return ok
+	}, nil
+}
+
+// patchPodSpec applies the mutation to the specification of the Pod, or of the EphemeralContainers object served
+// by the ephemeralcontainers subresource prior to Kubernetes 1.22, returning nil when nothing has been changed.
+func patchPodSpec(decoder *admission.Decoder, req admission.Request, mutate func(spec *corev1.PodSpec) bool) (*admission.Response, error) {
+	var obj interface{}
+	var spec *corev1.PodSpec
+
+	switch req.Kind.Kind {
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err := decoder.Decode(req, pod); err != nil {
+			return nil, err
+		}
+
+		obj, spec = pod, &pod.Spec
+	case "EphemeralContainers":
+		ec := &corev1.EphemeralContainers{}
+		if err := decoder.Decode(req, ec); err != nil {
+			return nil, err
+		}
+
+		obj, spec = ec, &corev1.PodSpec{EphemeralContainers: ec.EphemeralContainers}
+	default:
+		return nil, fmt.Errorf("unexpected %s kind, expected a Pod", req.Kind.Kind)
+	}
+
+	if !mutate(spec) {
+		return nil, nil
+	}
+
+	if ec, ok := obj.(*corev1.EphemeralContainers); ok {
+		ec.EphemeralContainers = spec.EphemeralContainers
+	}
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+	return &response, nil
+}
@@ -23,51 +23,69 @@ func ImagePullPolicy() capsulewebhook.Handler {
 	return &imagePullPolicy{}
 }
 
-func (r *imagePullPolicy) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
-	return func(ctx context.Context, req admission.Request) *admission.Response {
-		var pod = &corev1.Pod{}
-		if err := decoder.Decode(req, pod); err != nil {
-			return utils.ErroredResponse(err)
-		}
+func (r *imagePullPolicy) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder, containers []container) *admission.Response {
+	if len(containers) == 0 {
+		return nil
+	}
 
-		var tntList = &capsulev1beta1.TenantList{}
-		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector(".status.namespaces", pod.Namespace),
-		}); err != nil {
-			return utils.ErroredResponse(err)
-		}
-		// the Pod is not running in a Namespace managed by a Tenant
-		if len(tntList.Items) == 0 {
-			return nil
-		}
+	var tntList = &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+	// the Pod is not running in a Namespace managed by a Tenant
+	if len(tntList.Items) == 0 {
+		return nil
+	}
 
-		tnt := tntList.Items[0]
+	tnt := tntList.Items[0]
 
-		policy := NewPullPolicy(&tnt)
-		// if Tenant doesn't enforce the pull policy, exit
-		if policy == nil {
-			return nil
+	policy := NewPullPolicy(&tnt)
+	// if Tenant doesn't enforce the pull policy, exit
+	if policy == nil {
+		return nil
+	}
+
+	for _, container := range containers {
+		usedPullPolicy := string(container.pullPolicy)
+		// the default pull policy has been already set to the Pod containers, while Pod templates are validated
+		// against the one their Pods are going to be mutated to
+		if !isPod(req) {
+			usedPullPolicy = defaultPullPolicy(&tnt, usedPullPolicy)
 		}
 
-		for _, container := range pod.Spec.Containers {
-			usedPullPolicy := string(container.ImagePullPolicy)
+		if !policy.IsPolicySupported(usedPullPolicy) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenPullPolicy", "%s %s/%s pull policy %s is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, usedPullPolicy)
 
-			if !policy.IsPolicySupported(usedPullPolicy) {
-				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenPullPolicy", "Pod %s/%s pull policy %s is forbidden for the current Tenant", req.Namespace, req.Name, usedPullPolicy)
+			response := admission.Denied(NewImagePullPolicyForbidden(usedPullPolicy, container.name, policy.AllowedPullPolicies()).Error())
 
-				response := admission.Denied(NewImagePullPolicyForbidden(usedPullPolicy, container.Name, policy.AllowedPullPolicies()).Error())
+			return &response
+		}
+	}
 
-				return &response
-			}
+	return nil
+}
+
+func (r *imagePullPolicy) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		containers, err := decodeContainers(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
 		}
 
-		return nil
+		return r.validate(ctx, c, req, recorder, containers)
 	}
 }
 
-func (r *imagePullPolicy) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+func (r *imagePullPolicy) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		return nil
+		containers, err := changedContainers(decoder, req)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return r.validate(ctx, c, req, recorder, containers)
 	}
 }
 
@@ -0,0 +1,101 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type imagePullPolicyDefault struct{}
+
+func ImagePullPolicyDefault() capsulewebhook.Handler {
+	return &imagePullPolicyDefault{}
+}
+
+func (r *imagePullPolicyDefault) mutate(ctx context.Context, c client.Client, decoder *admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	var tntList = &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+	// the Pod is not running in a Namespace managed by a Tenant
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+	// if Tenant doesn't specify a default pull policy, exit
+	if tnt.Spec.ContainerImages == nil || len(tnt.Spec.ContainerImages.DefaultPullPolicy) == 0 {
+		return nil
+	}
+
+	mutable, err := mutableContainers(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	response, err := patchPodSpec(decoder, req, func(spec *corev1.PodSpec) (mutated bool) {
+		mutate := func(name string, policy *corev1.PullPolicy) {
+			if !mutable(name) {
+				return
+			}
+
+			if value := corev1.PullPolicy(defaultPullPolicy(&tnt, string(*policy))); value != *policy {
+				recorder.Eventf(&tnt, corev1.EventTypeNormal, "DefaultedPullPolicy", "Pod %s/%s container %s pull policy %s has been set to %s", req.Namespace, req.Name, name, *policy, value)
+
+				*policy = value
+				mutated = true
+			}
+		}
+		// the pull policy of the Pod containers is immutable, only the added ephemeral containers can be defaulted
+		if req.OldObject.Raw == nil {
+			for i := range spec.InitContainers {
+				mutate(spec.InitContainers[i].Name, &spec.InitContainers[i].ImagePullPolicy)
+			}
+			for i := range spec.Containers {
+				mutate(spec.Containers[i].Name, &spec.Containers[i].ImagePullPolicy)
+			}
+		}
+		for i := range spec.EphemeralContainers {
+			mutate(spec.EphemeralContainers[i].Name, &spec.EphemeralContainers[i].ImagePullPolicy)
+		}
+
+		return
+	})
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	return response
+}
+
+func (r *imagePullPolicyDefault) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.mutate(ctx, c, decoder, recorder, req)
+	}
+}
+
+// OnUpdate sets the default pull policy of the added ephemeral containers.
+func (r *imagePullPolicyDefault) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.mutate(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (r *imagePullPolicyDefault) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
@@ -32,6 +32,22 @@ func (i imagePullPolicyValidator) AllowedPullPolicies() []string {
 	return i.allowedPolicies
 }
 
+// defaultPullPolicy returns the pull policy the Tenant mutates the given one to, the given one itself
+// if the Tenant doesn't specify a default, or the given one is set and allowed.
+func defaultPullPolicy(tenant *capsulev1beta1.Tenant, policy string) string {
+	if tenant.Spec.ContainerImages == nil || len(tenant.Spec.ContainerImages.DefaultPullPolicy) == 0 {
+		return policy
+	}
+
+	if len(policy) > 0 {
+		if validator := NewPullPolicy(tenant); validator == nil || validator.IsPolicySupported(policy) {
+			return policy
+		}
+	}
+
+	return tenant.Spec.ContainerImages.DefaultPullPolicy.String()
+}
+
 func NewPullPolicy(tenant *capsulev1beta1.Tenant) PullPolicy {
 	// the Tenant doesn't enforce the allowed image pull policy, returning nil
 	if len(tenant.Spec.ImagePullPolicies) == 0 {
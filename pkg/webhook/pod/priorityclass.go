@@ -24,44 +24,53 @@ func PriorityClass() capsulewebhook.Handler {
 	return &priorityClass{}
 }
 
-func (h *priorityClass) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
-	return func(ctx context.Context, req admission.Request) *admission.Response {
-		var pod = &corev1.Pod{}
-		if err := decoder.Decode(req, pod); err != nil {
-			return utils.ErroredResponse(err)
-		}
+func (h *priorityClass) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder, priorityClassName string) *admission.Response {
+	var tntList = &capsulev1beta1.TenantList{}
 
-		var tntList = &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
 
-		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector(".status.namespaces", pod.Namespace),
-		}); err != nil {
-			return utils.ErroredResponse(err)
-		}
+	if len(tntList.Items) == 0 {
+		return nil
+	}
 
-		if len(tntList.Items) == 0 {
-			return nil
-		}
+	allowed := tntList.Items[0].Spec.PriorityClasses
 
-		allowed := tntList.Items[0].Spec.PriorityClasses
-		var priorityClassName = pod.Spec.PriorityClassName
+	switch {
+	case allowed == nil:
+		// Enforcement is not in place, skipping it at all
+		return nil
+	case len(priorityClassName) == 0 && allowed.Required:
+		recorder.Eventf(&tntList.Items[0], corev1.EventTypeWarning, "MissingPriorityClass", "%s %s/%s is missing Priority Class", req.Kind.Kind, req.Namespace, req.Name)
 
-		switch {
-		case allowed == nil:
-			// Enforcement is not in place, skipping it at all
-			return nil
-		case len(priorityClassName) == 0:
-			// We don't have to force Pod to specify a Priority Class
-			return nil
-		case !allowed.ExactMatch(priorityClassName) && !allowed.RegexMatch(priorityClassName):
-			recorder.Eventf(&tntList.Items[0], corev1.EventTypeWarning, "ForbiddenPriorityClass", "Pod %s/%s is using Priority Class %s is forbidden for the current Tenant", pod.Namespace, pod.Name, priorityClassName)
+		response := admission.Denied(NewPodPriorityClassMissing(*allowed).Error())
+
+		return &response
+	case len(priorityClassName) == 0:
+		// We don't have to force Pod to specify a Priority Class, the default one is set upon the Pod creation
+		return nil
+	case !allowed.ExactMatch(priorityClassName) && !allowed.RegexMatch(priorityClassName):
+		recorder.Eventf(&tntList.Items[0], corev1.EventTypeWarning, "ForbiddenPriorityClass", "%s %s/%s is using Priority Class %s is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, priorityClassName)
 
-			response := admission.Denied(NewPodPriorityClassForbidden(priorityClassName, *allowed).Error())
+		response := admission.Denied(NewPodPriorityClassForbidden(priorityClassName, allowed.AllowedListSpec).Error())
 
-			return &response
-		default:
-			return nil
+		return &response
+	default:
+		return nil
+	}
+}
+
+func (h *priorityClass) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		spec, err := decodePodSpec(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
 		}
+
+		return h.validate(ctx, c, req, recorder, spec.PriorityClassName)
 	}
 }
 
@@ -71,8 +80,22 @@ func (h *priorityClass) OnDelete(client.Client, *admission.Decoder, record.Event
 	}
 }
 
-func (h *priorityClass) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+func (h *priorityClass) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
-		return nil
+		spec, err := decodePodSpec(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		oldSpec, err := decodePodSpec(decoder, req, req.OldObject)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		// the Pod Priority Class is immutable, although the one of a Pod template can be changed
+		if spec.PriorityClassName == oldSpec.PriorityClassName {
+			return nil
+		}
+
+		return h.validate(ctx, c, req, recorder, spec.PriorityClassName)
 	}
 }
@@ -0,0 +1,110 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type priorityClassDefault struct {
+}
+
+func PriorityClassDefault() capsulewebhook.Handler {
+	return &priorityClassDefault{}
+}
+
+func (h *priorityClassDefault) isClusterDefault(ctx context.Context, c client.Client) func(string) (bool, error) {
+	return func(name string) (bool, error) {
+		pc := &schedulingv1.PriorityClass{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return pc.GlobalDefault, nil
+	}
+}
+
+func (h *priorityClassDefault) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		pod := &corev1.Pod{}
+		if err := decoder.Decode(req, pod); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		var tntList = &capsulev1beta1.TenantList{}
+		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+		}); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if len(tntList.Items) == 0 {
+			return nil
+		}
+
+		tnt := tntList.Items[0]
+
+		allowed := tnt.Spec.PriorityClasses
+		if allowed == nil || !allowed.IsDefaulted() {
+			return nil
+		}
+
+		omitted, err := utils.IsClassOmitted(allowed, pod.Spec.PriorityClassName, h.isClusterDefault(ctx, c))
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if !omitted {
+			return nil
+		}
+		// the Pod priority is resolved by the API Server prior to the admission webhooks, thus it must be set as well
+		pc := &schedulingv1.PriorityClass{}
+		if err = c.Get(ctx, types.NamespacedName{Name: allowed.Default}, pc); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		recorder.Eventf(&tnt, corev1.EventTypeNormal, "DefaultedPriorityClass", "Pod %s/%s Priority Class has been set to %s", req.Namespace, req.Name, pc.GetName())
+
+		pod.Spec.PriorityClassName = pc.GetName()
+		pod.Spec.Priority = &pc.Value
+		pod.Spec.PreemptionPolicy = pc.PreemptionPolicy
+
+		marshaled, err := json.Marshal(pod)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+		return &response
+	}
+}
+
+func (h *priorityClassDefault) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *priorityClassDefault) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
@@ -35,3 +35,21 @@ func (f podPriorityClassForbidden) Error() (err string) {
 	err += strings.Join(extra, " or ")
 	return
 }
+
+type podPriorityClassMissing struct {
+	spec capsulev1beta1.DefaultAllowedListSpec
+}
+
+func NewPodPriorityClassMissing(spec capsulev1beta1.DefaultAllowedListSpec) error {
+	return &podPriorityClassMissing{
+		spec: spec,
+	}
+}
+
+func (f podPriorityClassMissing) Error() (err string) {
+	err = "A Pod Priority Class must be specified for the current Tenant"
+	if len(f.spec.Default) > 0 {
+		err += fmt.Sprintf(", such as the default one (%s)", f.spec.Default)
+	}
+	return
+}
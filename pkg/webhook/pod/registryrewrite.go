@@ -0,0 +1,119 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type registryRewriteHandler struct {
+}
+
+func RegistryRewrite() capsulewebhook.Handler {
+	return &registryRewriteHandler{}
+}
+
+// rewriteImage returns the image the Tenant registry rewrites are mapping the given one to, the image itself
+// if none is matching: invalid references are left untouched, since these are rejected by the validation.
+func rewriteImage(spec *capsulev1beta1.ContainerImagesSpec, image string) string {
+	if spec == nil || len(spec.RegistryRewrites) == 0 {
+		return image
+	}
+
+	reference, err := NewRegistry(image)
+	if err != nil {
+		return image
+	}
+
+	if rewritten, ok := reference.Rewrite(spec.RegistryRewrites); ok {
+		return rewritten
+	}
+
+	return image
+}
+
+func (h *registryRewriteHandler) rewrite(ctx context.Context, c client.Client, decoder *admission.Decoder, recorder record.EventRecorder, req admission.Request) *admission.Response {
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+
+	if tnt.Spec.ContainerImages == nil || len(tnt.Spec.ContainerImages.RegistryRewrites) == 0 {
+		return nil
+	}
+
+	mutable, err := mutableContainers(decoder, req)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	response, err := patchPodSpec(decoder, req, func(spec *corev1.PodSpec) (rewritten bool) {
+		rewrite := func(name string, image *string) {
+			if !mutable(name) {
+				return
+			}
+
+			if value := rewriteImage(tnt.Spec.ContainerImages, *image); value != *image {
+				recorder.Eventf(&tnt, corev1.EventTypeNormal, "ContainerImageRewritten", "Pod %s/%s container %s image %s has been rewritten to %s", req.Namespace, req.Name, name, *image, value)
+
+				*image = value
+				rewritten = true
+			}
+		}
+
+		for i := range spec.InitContainers {
+			rewrite(spec.InitContainers[i].Name, &spec.InitContainers[i].Image)
+		}
+		for i := range spec.Containers {
+			rewrite(spec.Containers[i].Name, &spec.Containers[i].Image)
+		}
+		for i := range spec.EphemeralContainers {
+			rewrite(spec.EphemeralContainers[i].Name, &spec.EphemeralContainers[i].Image)
+		}
+
+		return
+	})
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	return response
+}
+
+func (h *registryRewriteHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.rewrite(ctx, c, decoder, recorder, req)
+	}
+}
+
+func (h *registryRewriteHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// OnUpdate rewrites the images of the added ephemeral containers, and the changed images of the Pod containers.
+func (h *registryRewriteHandler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.rewrite(ctx, c, decoder, recorder, req)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pvc
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// validateSize returns an error if the storage requested by a PersistentVolumeClaim, or a template, exceeds the Tenant maximum size.
+func validateSize(spec *capsulev1beta1.PersistentVolumeClaimsSpec, resources corev1.ResourceRequirements) error {
+	if spec == nil || spec.MaxSize == nil {
+		return nil
+	}
+
+	size, ok := resources.Requests[corev1.ResourceStorage]
+	if !ok || size.Cmp(*spec.MaxSize) <= 0 {
+		return nil
+	}
+
+	return NewStorageSizeExceeded(size, *spec.MaxSize)
+}
+
+// validateStorageClass returns an error if the Storage Class of a PersistentVolumeClaim template is not allowed: a template not
+// specifying it is accepted only if the Tenant has a default one, since the PersistentVolumeClaim is mutated upon its creation.
+func validateStorageClass(allowed *capsulev1beta1.DefaultAllowedListSpec, storageClassName *string) error {
+	if allowed == nil {
+		return nil
+	}
+
+	if storageClassName == nil || len(*storageClassName) == 0 {
+		if allowed.IsDefaulted() {
+			return nil
+		}
+
+		return NewStorageClassNotValid(allowed.AllowedListSpec)
+	}
+
+	if !allowed.ExactMatch(*storageClassName) && !allowed.RegexMatch(*storageClassName) {
+		return NewStorageClassForbidden(*storageClassName, allowed.AllowedListSpec)
+	}
+
+	return nil
+}
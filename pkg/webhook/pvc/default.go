@@ -0,0 +1,117 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pvc
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+const (
+	isDefaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	isBetaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+type defaultHandler struct {
+}
+
+func StorageClassDefault() capsulewebhook.Handler {
+	return &defaultHandler{}
+}
+
+func (h *defaultHandler) isClusterDefault(ctx context.Context, c client.Client) func(string) (bool, error) {
+	return func(name string) (bool, error) {
+		sc := &storagev1.StorageClass{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, sc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return sc.GetAnnotations()[isDefaultStorageClassAnnotation] == "true" || sc.GetAnnotations()[isBetaDefaultStorageClassAnnotation] == "true", nil
+	}
+}
+
+func (h *defaultHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := decoder.Decode(req, pvc); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		tntList := &capsulev1beta1.TenantList{}
+		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+		}); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if len(tntList.Items) == 0 {
+			return nil
+		}
+
+		tnt := tntList.Items[0]
+
+		allowed := tnt.Spec.StorageClasses
+		if allowed == nil || !allowed.IsDefaulted() {
+			return nil
+		}
+
+		var storageClassName string
+		if pvc.Spec.StorageClassName != nil {
+			// an explicitly empty Storage Class disables the dynamic provisioning, binding a pre-provisioned PersistentVolume
+			if len(*pvc.Spec.StorageClassName) == 0 {
+				return nil
+			}
+			storageClassName = *pvc.Spec.StorageClassName
+		}
+
+		omitted, err := utils.IsClassOmitted(allowed, storageClassName, h.isClusterDefault(ctx, c))
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if !omitted {
+			return nil
+		}
+
+		recorder.Eventf(&tnt, corev1.EventTypeNormal, "DefaultedStorageClass", "PersistentVolumeClaim %s/%s StorageClass has been set to %s", req.Namespace, req.Name, allowed.Default)
+
+		pvc.Spec.StorageClassName = &allowed.Default
+
+		marshaled, err := json.Marshal(pvc)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+		return &response
+	}
+}
+
+func (h *defaultHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *defaultHandler) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
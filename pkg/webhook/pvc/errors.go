@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
 )
 
@@ -49,3 +51,30 @@ func NewStorageClassForbidden(className string, storageClasses capsulev1beta1.Al
 func (f storageClassForbidden) Error() string {
 	return fmt.Sprintf("Storage Class %s is forbidden for the current Tenant%s", f.className, appendError(f.spec))
 }
+
+type storageSizeExceeded struct {
+	size    resource.Quantity
+	maxSize resource.Quantity
+}
+
+func NewStorageSizeExceeded(size, maxSize resource.Quantity) error {
+	return &storageSizeExceeded{
+		size:    size,
+		maxSize: maxSize,
+	}
+}
+
+func (s storageSizeExceeded) Error() string {
+	return fmt.Sprintf("Storage request %s exceeds the maximum size allowed for the current Tenant (%s)", s.size.String(), s.maxSize.String())
+}
+
+type storageExpansionForbidden struct {
+}
+
+func NewStorageExpansionForbidden() error {
+	return &storageExpansionForbidden{}
+}
+
+func (storageExpansionForbidden) Error() string {
+	return "PersistentVolumeClaim expansion is forbidden for the current Tenant"
+}
@@ -0,0 +1,198 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package pvc
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+// claimTemplate describes a PersistentVolumeClaim template, either of a generic ephemeral volume or of a StatefulSet.
+type claimTemplate struct {
+	name string
+	spec corev1.PersistentVolumeClaimSpec
+}
+
+type templatesHandler struct {
+}
+
+// Templates validates the PersistentVolumeClaim templates of Pods and workload controllers: the PersistentVolumeClaim resources
+// are created by the Kubernetes controllers, thus rejecting them would leave the Pods pending rather than failing the request.
+func Templates() capsulewebhook.Handler {
+	return &templatesHandler{}
+}
+
+func ephemeralTemplates(volumes []corev1.Volume) (templates []claimTemplate) {
+	for _, v := range volumes {
+		if v.Ephemeral == nil || v.Ephemeral.VolumeClaimTemplate == nil {
+			continue
+		}
+		templates = append(templates, claimTemplate{name: "volume " + v.Name, spec: v.Ephemeral.VolumeClaimTemplate.Spec})
+	}
+
+	return
+}
+
+// decodeTemplates returns the PersistentVolumeClaim templates of the Pod, or of the workload controller, in the request:
+// the latter is decoded as unstructured since the served API versions are depending on the cluster version.
+func decodeTemplates(decoder *admission.Decoder, kind string, raw runtime.RawExtension) ([]claimTemplate, error) {
+	switch kind {
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err := decoder.DecodeRaw(raw, pod); err != nil {
+			return nil, err
+		}
+
+		return ephemeralTemplates(pod.Spec.Volumes), nil
+	case "EphemeralContainers":
+		return nil, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := decoder.DecodeRaw(raw, obj); err != nil {
+		return nil, err
+	}
+
+	path := []string{"spec", "template", "spec"}
+	if kind == "CronJob" {
+		path = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+
+	podSpec, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s %s/%s has no Pod template", kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	spec := &corev1.PodSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(podSpec, spec); err != nil {
+		return nil, err
+	}
+
+	templates := ephemeralTemplates(spec.Volumes)
+
+	if kind == "StatefulSet" {
+		items, _, err := unstructured.NestedSlice(obj.Object, "spec", "volumeClaimTemplates")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			claim, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s %s/%s has a malformed volumeClaimTemplate", kind, obj.GetNamespace(), obj.GetName())
+			}
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(claim, pvc); err != nil {
+				return nil, err
+			}
+			templates = append(templates, claimTemplate{name: "volumeClaimTemplate " + pvc.Name, spec: pvc.Spec})
+		}
+	}
+
+	return templates, nil
+}
+
+func (h *templatesHandler) validate(ctx context.Context, c client.Client, req admission.Request, recorder record.EventRecorder, templates []claimTemplate) *admission.Response {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+
+	for _, t := range templates {
+		if err := validateStorageClass(tnt.Spec.StorageClasses, t.spec.StorageClassName); err != nil {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenStorageClass", "%s %s/%s %s StorageClass is forbidden for the current Tenant", req.Kind.Kind, req.Namespace, req.Name, t.name)
+
+			response := admission.Denied(err.Error())
+
+			return &response
+		}
+
+		if err := validateSize(tnt.Spec.PersistentVolumeClaims, t.spec.Resources); err != nil {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExceededStorageSize", "%s %s/%s %s %s", req.Kind.Kind, req.Namespace, req.Name, t.name, err.Error())
+
+			response := admission.Denied(err.Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
+
+func (h *templatesHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		templates, err := decodeTemplates(decoder, req.Kind.Kind, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.validate(ctx, c, req, recorder, templates)
+	}
+}
+
+func (h *templatesHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// OnUpdate validates only the added or changed templates: the unchanged ones have been already validated,
+// and must not be rejected due to a later change of the Tenant policies.
+func (h *templatesHandler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		templates, err := decodeTemplates(decoder, req.Kind.Kind, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		oldTemplates, err := decodeTemplates(decoder, req.Kind.Kind, req.OldObject)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		existing := make(map[string]corev1.PersistentVolumeClaimSpec, len(oldTemplates))
+		for _, t := range oldTemplates {
+			existing[t.name] = t.spec
+		}
+
+		var changed []claimTemplate
+		for _, t := range templates {
+			if old, ok := existing[t.name]; ok && equality.Semantic.DeepEqual(old, t.spec) {
+				continue
+			}
+			changed = append(changed, t)
+		}
+
+		return h.validate(ctx, c, req, recorder, changed)
+	}
+}
@@ -46,25 +46,31 @@ func (h *handler) OnCreate(c client.Client, decoder *admission.Decoder, recorder
 
 		tnt := tntList.Items[0]
 
-		if tnt.Spec.StorageClasses == nil {
-			return nil
-		}
+		if tnt.Spec.StorageClasses != nil {
+			if pvc.Spec.StorageClassName == nil {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingStorageClass", "PersistentVolumeClaim %s/%s is missing StorageClass", req.Namespace, req.Name)
 
-		if pvc.Spec.StorageClassName == nil {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingStorageClass", "PersistentVolumeClaim %s/%s is missing StorageClass", req.Namespace, req.Name)
+				response := admission.Denied(NewStorageClassNotValid(tnt.Spec.StorageClasses.AllowedListSpec).Error())
 
-			response := admission.Denied(NewStorageClassNotValid(*tntList.Items[0].Spec.StorageClasses).Error())
+				return &response
+			}
 
-			return &response
+			sc := *pvc.Spec.StorageClassName
+			valid = tnt.Spec.StorageClasses.ExactMatch(sc)
+			matched = tnt.Spec.StorageClasses.RegexMatch(sc)
+			if !valid && !matched {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenStorageClass", "PersistentVolumeClaim %s/%s StorageClass %s is forbidden for the current Tenant", req.Namespace, req.Name, sc)
+
+				response := admission.Denied(NewStorageClassForbidden(*pvc.Spec.StorageClassName, tnt.Spec.StorageClasses.AllowedListSpec).Error())
+
+				return &response
+			}
 		}
 
-		sc := *pvc.Spec.StorageClassName
-		valid = tnt.Spec.StorageClasses.ExactMatch(sc)
-		matched = tnt.Spec.StorageClasses.RegexMatch(sc)
-		if !valid && !matched {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenStorageClass", "PersistentVolumeClaim %s/%s StorageClass %s is forbidden for the current Tenant", req.Namespace, req.Name, sc)
+		if err := validateSize(tnt.Spec.PersistentVolumeClaims, pvc.Spec.Resources); err != nil {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExceededStorageSize", "PersistentVolumeClaim %s/%s %s", req.Namespace, req.Name, err.Error())
 
-			response := admission.Denied(NewStorageClassForbidden(*pvc.Spec.StorageClassName, *tnt.Spec.StorageClasses).Error())
+			response := admission.Denied(err.Error())
 
 			return &response
 		}
@@ -79,8 +85,51 @@ func (h *handler) OnDelete(client.Client, *admission.Decoder, record.EventRecord
 	}
 }
 
-func (h *handler) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+func (h *handler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
+		pvc, old := &corev1.PersistentVolumeClaim{}, &corev1.PersistentVolumeClaim{}
+		if err := decoder.DecodeRaw(req.Object, pvc); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if err := decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		// the storage request can only be increased, expanding the volume: any other update has been already validated
+		size, oldSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage], old.Spec.Resources.Requests[corev1.ResourceStorage]
+		if size.Cmp(oldSize) <= 0 {
+			return nil
+		}
+
+		tntList := &capsulev1beta1.TenantList{}
+		if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+		}); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if len(tntList.Items) == 0 || tntList.Items[0].Spec.PersistentVolumeClaims == nil {
+			return nil
+		}
+
+		tnt := tntList.Items[0]
+
+		if !tnt.Spec.PersistentVolumeClaims.IsExpansionAllowed() {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenStorageExpansion", "PersistentVolumeClaim %s/%s cannot be expanded from %s to %s", req.Namespace, req.Name, oldSize.String(), size.String())
+
+			response := admission.Denied(NewStorageExpansionForbidden().Error())
+
+			return &response
+		}
+
+		if err := validateSize(tnt.Spec.PersistentVolumeClaims, pvc.Spec.Resources); err != nil {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExceededStorageSize", "PersistentVolumeClaim %s/%s %s", req.Namespace, req.Name, err.Error())
+
+			response := admission.Denied(err.Error())
+
+			return &response
+		}
+
 		return nil
 	}
 }
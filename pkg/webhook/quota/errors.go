@@ -0,0 +1,31 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type quotaExceeded struct {
+	name      corev1.ResourceName
+	requested resource.Quantity
+	used      resource.Quantity
+	hard      resource.Quantity
+}
+
+func NewQuotaExceeded(name corev1.ResourceName, requested, used, hard resource.Quantity) error {
+	return &quotaExceeded{
+		name:      name,
+		requested: requested,
+		used:      used,
+		hard:      hard,
+	}
+}
+
+func (q quotaExceeded) Error() string {
+	return fmt.Sprintf("Tenant quota exceeded for %s: requested %s, used %s, limited to %s", q.name, q.requested.String(), q.used.String(), q.hard.String())
+}
@@ -0,0 +1,212 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/quota"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+// handler reserves the resources required by Pods, PersistentVolumeClaims, and Services
+// in the Tenant status: the reservation is performed using the optimistic concurrency of
+// the Tenant resource version, so two concurrent requests cannot exceed the Tenant quota.
+type handler struct {
+	reader client.Reader
+}
+
+// Handler must be the last one of the chain, since an allowed request reserves the quota: the chain stops
+// at the first denial, thus the requests rejected by Capsule never reserve it. A request rejected afterwards,
+// such as by another admission controller, keeps the reservation until the Tenant controller observes the
+// actual usage, once the reservation grace period is over.
+// The provided reader should not be backed by the cache, since the reservation requires
+// the latest Tenant resource version.
+func Handler(reader client.Reader) capsulewebhook.Handler {
+	return &handler{reader: reader}
+}
+
+func (h *handler) decode(decoder *admission.Decoder, req admission.Request, raw runtime.RawExtension) (obj client.Object, err error) {
+	switch req.Kind.Kind {
+	case "Pod":
+		obj = &corev1.Pod{}
+	case "PersistentVolumeClaim":
+		obj = &corev1.PersistentVolumeClaim{}
+	case "Service":
+		obj = &corev1.Service{}
+	default:
+		return nil, nil
+	}
+
+	if err = decoder.DecodeRaw(raw, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (h *handler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		obj, err := h.decode(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if obj == nil {
+			return nil
+		}
+
+		return h.reserve(ctx, c, recorder, req, quota.Usage(obj))
+	}
+}
+
+func (h *handler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		newObj, err := h.decode(decoder, req, req.Object)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		oldObj, err := h.decode(decoder, req, req.OldObject)
+		if err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if newObj == nil || oldObj == nil {
+			return nil
+		}
+
+		return h.reserve(ctx, c, recorder, req, quota.Delta(quota.Usage(newObj), quota.Usage(oldObj)))
+	}
+}
+
+func (h *handler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// isReserving returns true if any requested resource is tracked by a Tenant scoped quota item.
+func (h *handler) isReserving(tnt *capsulev1beta1.Tenant, requested corev1.ResourceList) bool {
+	if tnt.Spec.ResourceQuota == nil {
+		return false
+	}
+
+	for _, item := range tnt.Spec.ResourceQuota.Items {
+		if !item.IsTenantScoped() {
+			continue
+		}
+
+		for name := range item.Hard {
+			if quantity, ok := requested[name]; ok && quantity.Sign() > 0 && quota.IsTracked(name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (h *handler) reserve(ctx context.Context, c client.Client, recorder record.EventRecorder, req admission.Request, requested corev1.ResourceList) *admission.Response {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	// the Tenant status is written only when the requested resources are limited at Tenant level,
+	// since the reservations of the same Tenant are serialized by its resource version
+	if len(tntList.Items) == 0 || !h.isReserving(&tntList.Items[0], requested) {
+		return nil
+	}
+
+	tnt := &capsulev1beta1.Tenant{}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := h.reader.Get(ctx, types.NamespacedName{Name: tntList.Items[0].GetName()}, tnt); err != nil {
+			return err
+		}
+
+		if tnt.Spec.ResourceQuota == nil {
+			return nil
+		}
+
+		var reserved bool
+
+		now := metav1.Now()
+
+		for index, item := range tnt.Spec.ResourceQuota.Items {
+			// Namespace scoped items are enforced by the ResourceQuota of each Namespace
+			if !item.IsTenantScoped() {
+				continue
+			}
+
+			status := tnt.Status.GetResourceQuotaStatus(index)
+			if status == nil {
+				tnt.Status.ResourceQuotas = append(tnt.Status.ResourceQuotas, capsulev1beta1.TenantResourceQuotaStatus{Index: index})
+				status = &tnt.Status.ResourceQuotas[len(tnt.Status.ResourceQuotas)-1]
+			}
+			if status.Used == nil {
+				status.Used = corev1.ResourceList{}
+			}
+			status.Hard = item.Hard
+
+			for name, hard := range item.Hard {
+				quantity, ok := requested[name]
+				if !ok || !quota.IsTracked(name) {
+					continue
+				}
+
+				used := status.Used[name]
+
+				total := used.DeepCopy()
+				total.Add(quantity)
+				if total.Cmp(hard) > 0 {
+					return NewQuotaExceeded(name, quantity, used, hard)
+				}
+
+				status.Used[name] = total
+				status.LastReservationTime = &now
+				reserved = true
+			}
+		}
+
+		// Dry-run requests are checked against the quota without reserving it
+		if !reserved || (req.DryRun != nil && *req.DryRun) {
+			return nil
+		}
+
+		return c.Status().Update(ctx, tnt)
+	})
+
+	var exceeded *quotaExceeded
+	if errors.As(err, &exceeded) {
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "TenantQuotaExceeded", "%s %s/%s cannot be admitted: %s", req.Kind.Kind, req.Namespace, req.Name, err.Error())
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	return nil
+}
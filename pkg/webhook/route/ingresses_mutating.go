@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/ingresses-mutating,mutating=true,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups=networking.k8s.io;extensions,resources=ingresses,verbs=create,versions=v1beta1;v1,name=mutating.ingress.capsule.clastix.io
+
+type ingressMutating struct {
+	handlers []capsulewebhook.Handler
+}
+
+func IngressMutating(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &ingressMutating{handlers: handler}
+}
+
+func (w *ingressMutating) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *ingressMutating) GetPath() string {
+	return "/ingresses-mutating"
+}
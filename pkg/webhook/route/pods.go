@@ -7,7 +7,7 @@ import (
 	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
 )
 
-// +kubebuilder:webhook:path=/pods,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=pods,verbs=create,versions=v1,name=pods.capsule.clastix.io
+// +kubebuilder:webhook:path=/pods,mutating=false,sideEffects=NoneOnDryRun,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=pods;pods/ephemeralcontainers,verbs=create;update,versions=v1,name=pods.capsule.clastix.io
 
 type pod struct {
 	handlers []capsulewebhook.Handler
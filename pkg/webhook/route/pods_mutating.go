@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/pods-mutating,mutating=true,sideEffects=NoneOnDryRun,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=pods;pods/ephemeralcontainers,verbs=create;update,versions=v1,name=mutating.pods.capsule.clastix.io
+
+type podMutating struct {
+	handlers []capsulewebhook.Handler
+}
+
+func PodMutating(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &podMutating{handlers: handler}
+}
+
+func (w *podMutating) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *podMutating) GetPath() string {
+	return "/pods-mutating"
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/persistentvolumeclaims-mutating,mutating=true,sideEffects=NoneOnDryRun,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=persistentvolumeclaims,verbs=create,versions=v1,name=mutating.pvc.capsule.clastix.io
+
+type pvcMutating struct {
+	handlers []capsulewebhook.Handler
+}
+
+func PVCMutating(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &pvcMutating{handlers: handler}
+}
+
+func (w *pvcMutating) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *pvcMutating) GetPath() string {
+	return "/persistentvolumeclaims-mutating"
+}
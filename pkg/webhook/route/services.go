@@ -7,7 +7,7 @@ import (
 	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
 )
 
-// +kubebuilder:webhook:path=/services,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=services,verbs=create;update,versions=v1,name=services.capsule.clastix.io
+// +kubebuilder:webhook:path=/services,mutating=false,sideEffects=NoneOnDryRun,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=services;services/status,verbs=create;update,versions=v1,name=services.capsule.clastix.io
 
 type service struct {
 	handlers []capsulewebhook.Handler
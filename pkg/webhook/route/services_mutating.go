@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package route
+
+import (
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/services-mutating,mutating=true,sideEffects=NoneOnDryRun,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=services,verbs=create;update,versions=v1,name=mutating.services.capsule.clastix.io
+
+type serviceMutating struct {
+	handlers []capsulewebhook.Handler
+}
+
+func ServiceMutating(handler ...capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &serviceMutating{handlers: handler}
+}
+
+func (w *serviceMutating) GetHandlers() []capsulewebhook.Handler {
+	return w.handlers
+}
+
+func (w *serviceMutating) GetPath() string {
+	return "/services-mutating"
+}
@@ -4,10 +4,16 @@
 package webhook
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io/ioutil"
+	"net/http"
 
+	jsonpatchapply "github.com/evanphx/json-patch"
+	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,30 +52,69 @@ type handlerRouter struct {
 }
 
 func (r *handlerRouter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var fn func(h Handler) Func
+
 	switch req.Operation {
 	case admissionv1.Create:
-		for _, h := range r.handlers {
-			if response := h.OnCreate(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
-			}
+		fn = func(h Handler) Func {
+			return h.OnCreate(r.client, r.decoder, r.recorder)
 		}
 	case admissionv1.Update:
-		for _, h := range r.handlers {
-			if response := h.OnUpdate(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
-			}
+		fn = func(h Handler) Func {
+			return h.OnUpdate(r.client, r.decoder, r.recorder)
 		}
 	case admissionv1.Delete:
-		for _, h := range r.handlers {
-			if response := h.OnDelete(r.client, r.decoder, r.recorder)(ctx, req); response != nil {
-				return *response
+		fn = func(h Handler) Func {
+			return h.OnDelete(r.client, r.decoder, r.recorder)
+		}
+	default:
+		return admission.Allowed("")
+	}
+
+	original := req.Object.Raw
+
+	for _, h := range r.handlers {
+		response := fn(h)(ctx, req)
+		if response == nil {
+			continue
+		}
+		// A mutating handler allows the request with a patch: rather than returning it, the patch is applied
+		// to the object that is passed to the next handlers, letting them mutate or validate the patched object.
+		if response.Allowed && len(response.Patches) > 0 {
+			patched, err := applyPatches(req.Object.Raw, response.Patches)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
 			}
+
+			req.Object = runtime.RawExtension{Raw: patched}
+
+			continue
 		}
+
+		return *response
+	}
+
+	if !bytes.Equal(original, req.Object.Raw) {
+		return admission.PatchResponseFromRaw(original, req.Object.Raw)
 	}
 
 	return admission.Allowed("")
 }
 
+func applyPatches(raw []byte, operations []jsonpatch.JsonPatchOperation) ([]byte, error) {
+	ops, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := jsonpatchapply.DecodePatch(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return patch.Apply(raw)
+}
+
 func (r *handlerRouter) InjectClient(c client.Client) error {
 	r.client = c
 
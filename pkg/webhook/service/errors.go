@@ -51,3 +51,116 @@ func NewExternalNameDisabledError() error {
 func (externalNameDisabled) Error() string {
 	return "ExternalName service types are forbidden for the tenant: please, reach out to the system administrators"
 }
+
+type loadBalancerDisabled struct{}
+
+func NewLoadBalancerDisabled() error {
+	return &loadBalancerDisabled{}
+}
+
+func (loadBalancerDisabled) Error() string {
+	return "LoadBalancer service types are forbidden for the tenant: please, reach out to the system administrators"
+}
+
+type loadBalancerClassForbidden struct {
+	className string
+	spec      capsulev1beta1.AllowedListSpec
+}
+
+func NewLoadBalancerClassForbidden(className string, spec capsulev1beta1.AllowedListSpec) error {
+	return &loadBalancerClassForbidden{
+		className: className,
+		spec:      spec,
+	}
+}
+
+func (l loadBalancerClassForbidden) Error() (err string) {
+	if len(l.className) == 0 {
+		err = "A valid LoadBalancer class must be used"
+	} else {
+		err = fmt.Sprintf("LoadBalancer class %s is forbidden for the current Tenant", l.className)
+	}
+	if len(l.spec.Exact) > 0 {
+		err += fmt.Sprintf(", one of the following (%s)", strings.Join(l.spec.Exact, ", "))
+	}
+	if len(l.spec.Regex) > 0 {
+		err += fmt.Sprintf(", or matching the regex %s", l.spec.Regex)
+	}
+	return
+}
+
+type loadBalancerCountExceeded struct {
+	maxCount int32
+}
+
+func NewLoadBalancerCountExceeded(maxCount int32) error {
+	return &loadBalancerCountExceeded{
+		maxCount: maxCount,
+	}
+}
+
+func (l loadBalancerCountExceeded) Error() string {
+	return fmt.Sprintf("The current Tenant reached the maximum number of LoadBalancer services (%d): please, reach out to the system administrators", l.maxCount)
+}
+
+type loadBalancerSourceRangeForbidden struct {
+	cidr []string
+}
+
+func NewLoadBalancerSourceRangeForbidden(allowedRanges []capsulev1beta1.AllowedIP) error {
+	var cidr []string
+	for _, i := range allowedRanges {
+		cidr = append(cidr, string(i))
+	}
+	return &loadBalancerSourceRangeForbidden{
+		cidr: cidr,
+	}
+}
+
+func (l loadBalancerSourceRangeForbidden) Error() string {
+	return fmt.Sprintf("The LoadBalancer source ranges of the current Service must be specified, and contained in the following enforced CIDRs: %s", strings.Join(l.cidr, ", "))
+}
+
+func nodePortsAppend(spec capsulev1beta1.NodePortsSpec) (message string) {
+	var allowed []string
+	for _, p := range spec.Ports {
+		allowed = append(allowed, fmt.Sprintf("%d", p))
+	}
+	for _, r := range spec.Ranges {
+		allowed = append(allowed, fmt.Sprintf("%d-%d", r.From, r.To))
+	}
+	if len(allowed) > 0 {
+		message = fmt.Sprintf(", one of the following (%s)", strings.Join(allowed, ", "))
+	}
+	return
+}
+
+type nodePortForbidden struct {
+	port int32
+	spec capsulev1beta1.NodePortsSpec
+}
+
+func NewNodePortForbidden(port int32, spec capsulev1beta1.NodePortsSpec) error {
+	return &nodePortForbidden{
+		port: port,
+		spec: spec,
+	}
+}
+
+func (n nodePortForbidden) Error() string {
+	return fmt.Sprintf("Node port %d is forbidden for the current Tenant%s", n.port, nodePortsAppend(n.spec))
+}
+
+type nodePortsExhausted struct {
+	spec capsulev1beta1.NodePortsSpec
+}
+
+func NewNodePortsExhausted(spec capsulev1beta1.NodePortsSpec) error {
+	return &nodePortsExhausted{
+		spec: spec,
+	}
+}
+
+func (n nodePortsExhausted) Error() string {
+	return fmt.Sprintf("The node ports allowed for the current Tenant are exhausted%s: please, reach out to the system administrators", nodePortsAppend(n.spec))
+}
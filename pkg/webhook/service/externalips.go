@@ -0,0 +1,70 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// handleExternalIPs rejects the external IPs, and the requested load balancer IP, not allowed for the Tenant.
+func (r *handler) handleExternalIPs(req admission.Request, recorder record.EventRecorder, tnt capsulev1beta1.Tenant, svc *corev1.Service) *admission.Response {
+	if tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil {
+		return nil
+	}
+
+	spec := tnt.Spec.ServiceOptions.ExternalServiceIPs
+
+	for _, externalIP := range svc.Spec.ExternalIPs {
+		if !spec.Contains(net.ParseIP(strings.TrimSpace(externalIP))) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, externalIP)
+
+			response := admission.Denied(NewExternalServiceIPForbidden(spec.Allowed).Error())
+
+			return &response
+		}
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Spec.LoadBalancerIP) > 0 && !spec.Contains(net.ParseIP(strings.TrimSpace(svc.Spec.LoadBalancerIP))) {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s load balancer IP %s is forbidden for the current Tenant", req.Namespace, req.Name, svc.Spec.LoadBalancerIP)
+
+		response := admission.Denied(NewExternalServiceIPForbidden(spec.Allowed).Error())
+
+		return &response
+	}
+
+	return nil
+}
+
+// handleIngressIPs rejects the load balancer ingress IPs not allowed for the Tenant, the ones specifying a hostname are skipped:
+// it runs only for the Capsule users, the cloud controllers assigning them are never checked.
+func handleIngressIPs(req admission.Request, recorder record.EventRecorder, tnt capsulev1beta1.Tenant, svc *corev1.Service) *admission.Response {
+	if tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil {
+		return nil
+	}
+
+	spec := tnt.Spec.ServiceOptions.ExternalServiceIPs
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if len(ingress.IP) == 0 {
+			continue
+		}
+
+		if !spec.Contains(net.ParseIP(strings.TrimSpace(ingress.IP))) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s load balancer ingress IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ingress.IP)
+
+			response := admission.Denied(NewExternalServiceIPForbidden(spec.Allowed).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+// loadBalancerClass returns the loadBalancerClass of the given raw Service: the field is read from the raw
+// object since it has been introduced with Kubernetes 1.21, thus it's not available in the Service type in use.
+func loadBalancerClass(decoder *admission.Decoder, raw runtime.RawExtension) (string, error) {
+	obj := &unstructured.Unstructured{}
+	if err := decoder.DecodeRaw(raw, obj); err != nil {
+		return "", err
+	}
+
+	class, _, err := unstructured.NestedString(obj.Object, "spec", "loadBalancerClass")
+
+	return class, err
+}
+
+// countLoadBalancers returns the number of Services with type LoadBalancer in the Tenant namespaces, the given one excluded.
+// The Services are counted from the cache, thus the maximum count is enforced on a best-effort basis: concurrent
+// requests, or Services not yet observed by the cache, could exceed it.
+func countLoadBalancers(ctx context.Context, clt client.Client, tnt capsulev1beta1.Tenant, svc *corev1.Service) (count int32, err error) {
+	svcList := &corev1.ServiceList{}
+	if err = clt.List(ctx, svcList, client.MatchingFields{".spec.type": string(corev1.ServiceTypeLoadBalancer)}); err != nil {
+		return 0, err
+	}
+
+	namespaces := sets.NewString(tnt.Status.Namespaces...)
+
+	for _, item := range svcList.Items {
+		if !namespaces.Has(item.GetNamespace()) || (item.GetNamespace() == svc.GetNamespace() && item.GetName() == svc.GetName()) {
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// unchangedLoadBalancer returns true when the updated Service was already a LoadBalancer, with the same class and source ranges.
+func unchangedLoadBalancer(decoder *admission.Decoder, req admission.Request, class string, svc, old *corev1.Service) (bool, error) {
+	if old.Spec.Type != corev1.ServiceTypeLoadBalancer || !reflect.DeepEqual(old.Spec.LoadBalancerSourceRanges, svc.Spec.LoadBalancerSourceRanges) {
+		return false, nil
+	}
+
+	oldClass, err := loadBalancerClass(decoder, req.OldObject)
+	if err != nil {
+		return false, err
+	}
+
+	return oldClass == class, nil
+}
+
+func (r *handler) handleLoadBalancer(ctx context.Context, clt client.Client, decoder *admission.Decoder, req admission.Request, recorder record.EventRecorder, tnt capsulev1beta1.Tenant, svc, old *corev1.Service) *admission.Response {
+	if tnt.Spec.ServiceOptions == nil {
+		return nil
+	}
+	// the finalizers put by the cloud controllers must be removed, regardless of the Tenant policies
+	if svc.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	class, err := loadBalancerClass(decoder, req.Object)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if old != nil {
+		var unchanged bool
+		if unchanged, err = unchangedLoadBalancer(decoder, req, class, svc, old); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		// an update not changing the LoadBalancer has been already validated, and must not be rejected
+		// due to a later change of the Tenant policies
+		if unchanged {
+			return nil
+		}
+	}
+
+	if tnt.Spec.ServiceOptions.AllowedServices != nil && tnt.Spec.ServiceOptions.AllowedServices.LoadBalancer != nil && !*tnt.Spec.ServiceOptions.AllowedServices.LoadBalancer {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancer", "Service %s/%s cannot be type of LoadBalancer for the current Tenant", req.Namespace, req.Name)
+
+		response := admission.Denied(NewLoadBalancerDisabled().Error())
+
+		return &response
+	}
+
+	spec := tnt.Spec.ServiceOptions.LoadBalancers
+	if spec == nil {
+		return nil
+	}
+
+	if spec.AllowedClasses != nil {
+		if !spec.AllowedClasses.ExactMatch(class) && !spec.AllowedClasses.RegexMatch(class) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancerClass", "Service %s/%s LoadBalancer class %s is forbidden for the current Tenant", req.Namespace, req.Name, class)
+
+			response := admission.Denied(NewLoadBalancerClassForbidden(class, *spec.AllowedClasses).Error())
+
+			return &response
+		}
+	}
+
+	if len(spec.AllowedSourceRanges) > 0 {
+		if len(svc.Spec.LoadBalancerSourceRanges) == 0 {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancerSourceRange", "Service %s/%s is missing LoadBalancer source ranges", req.Namespace, req.Name)
+
+			response := admission.Denied(NewLoadBalancerSourceRangeForbidden(spec.AllowedSourceRanges).Error())
+
+			return &response
+		}
+
+		for _, sourceRange := range svc.Spec.LoadBalancerSourceRanges {
+			if _, network, parseErr := net.ParseCIDR(strings.TrimSpace(sourceRange)); parseErr != nil || !spec.ContainsSourceRange(network) {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancerSourceRange", "Service %s/%s LoadBalancer source range %s is forbidden for the current Tenant", req.Namespace, req.Name, sourceRange)
+
+				response := admission.Denied(NewLoadBalancerSourceRangeForbidden(spec.AllowedSourceRanges).Error())
+
+				return &response
+			}
+		}
+	}
+
+	if spec.MaxCount != nil {
+		// an update not changing the Service type doesn't add a LoadBalancer, thus must not be rejected
+		if old != nil && old.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			return nil
+		}
+
+		var count int32
+		if count, err = countLoadBalancers(ctx, clt, tnt, svc); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		if count >= *spec.MaxCount {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExceededLoadBalancers", "Service %s/%s cannot be type of LoadBalancer, the current Tenant reached the maximum number of %d", req.Namespace, req.Name, *spec.MaxCount)
+
+			response := admission.Denied(NewLoadBalancerCountExceeded(*spec.MaxCount).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
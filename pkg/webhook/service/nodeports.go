@@ -0,0 +1,73 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// needsNodePorts returns true if the API Server allocates node ports to the Service.
+func needsNodePorts(svc *corev1.Service) bool {
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeNodePort:
+		return true
+	case corev1.ServiceTypeLoadBalancer:
+		return svc.Spec.AllocateLoadBalancerNodePorts == nil || *svc.Spec.AllocateLoadBalancerNodePorts
+	default:
+		return false
+	}
+}
+
+// needsHealthCheckNodePort returns true if the API Server allocates the health check node port to the Service.
+func needsHealthCheckNodePort(svc *corev1.Service) bool {
+	return svc.Spec.Type == corev1.ServiceTypeLoadBalancer && svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal
+}
+
+// nodePorts returns the node ports specified by the Service, the health check one included.
+func nodePorts(svc *corev1.Service) (ports []int32) {
+	for _, p := range svc.Spec.Ports {
+		if p.NodePort > 0 {
+			ports = append(ports, p.NodePort)
+		}
+	}
+	if svc.Spec.HealthCheckNodePort > 0 {
+		ports = append(ports, svc.Spec.HealthCheckNodePort)
+	}
+
+	return
+}
+
+// handleNodePorts rejects the node ports not allowed for the Tenant: upon update, the node ports already allocated
+// to the Service are not validated, since they must not be rejected due to a later change of the Tenant policies.
+func (r *handler) handleNodePorts(req admission.Request, recorder record.EventRecorder, tnt capsulev1beta1.Tenant, svc, old *corev1.Service) *admission.Response {
+	existing := make(map[int32]struct{})
+
+	if old != nil {
+		for _, port := range nodePorts(old) {
+			existing[port] = struct{}{}
+		}
+	}
+
+	spec := tnt.Spec.ServiceOptions.NodePorts
+
+	for _, port := range nodePorts(svc) {
+		if _, ok := existing[port]; ok {
+			continue
+		}
+
+		if !spec.Contains(port) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenNodePortNumber", "Service %s/%s node port %d is forbidden for the current Tenant", req.Namespace, req.Name, port)
+
+			response := admission.Denied(NewNodePortForbidden(port, *spec).Error())
+
+			return &response
+		}
+	}
+
+	return nil
+}
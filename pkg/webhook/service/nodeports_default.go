@@ -0,0 +1,229 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/configuration"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+// nodePortReservationPeriod is the time a node port assigned by the webhook is kept reserved, waiting for the Service
+// to be observed by the cached index.
+const nodePortReservationPeriod = 30 * time.Second
+
+type nodePortDefaultHandler struct {
+	configuration configuration.Configuration
+
+	mu       sync.Mutex
+	reserved map[int32]time.Time
+}
+
+// NodePortDefault assigns to the Services not specifying their node ports a free one, among the ones allowed for the Tenant:
+// otherwise, the API Server would allocate them from the whole cluster range.
+func NodePortDefault(configuration configuration.Configuration) capsulewebhook.Handler {
+	return &nodePortDefaultHandler{
+		configuration: configuration,
+		reserved:      make(map[int32]time.Time),
+	}
+}
+
+// allocator returns the first free node port allowed for the Tenant, and contained in the node port range of the cluster.
+// The allocated node ports are collected once, listing the cached NodePort and LoadBalancer Services of the whole cluster,
+// since node ports are not scoped by Namespace: as the cache lags behind the API Server, the ports assigned by the webhook
+// are reserved until it catches up, holding the lock of the handler only to pick and reserve them.
+// Ports allocated concurrently by other replicas of the webhook are still rejected by the API Server, since they're already
+// allocated, rather than being assigned twice: in such case, the client has to retry the request.
+func (h *nodePortDefaultHandler) allocator(ctx context.Context, c client.Client, spec *capsulev1beta1.NodePortsSpec, svc *corev1.Service) (func() (int32, error), error) {
+	allocated := make(map[int32]struct{})
+	for _, port := range nodePorts(svc) {
+		allocated[port] = struct{}{}
+	}
+
+	for _, t := range []corev1.ServiceType{corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer} {
+		svcList := &corev1.ServiceList{}
+		if err := c.List(ctx, svcList, client.MatchingFields{".spec.type": string(t)}); err != nil {
+			return nil, err
+		}
+
+		for i := range svcList.Items {
+			for _, port := range nodePorts(&svcList.Items[i]) {
+				if spec.Contains(port) {
+					allocated[port] = struct{}{}
+				}
+			}
+		}
+	}
+
+	clusterRange := h.configuration.NodePortRange()
+
+	return func() (int32, error) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		now := time.Now()
+
+		for port, reservation := range h.reserved {
+			if now.Sub(reservation) > nodePortReservationPeriod {
+				delete(h.reserved, port)
+			}
+		}
+
+		port, ok := spec.NextCandidate(func(port int32) bool {
+			if port < clusterRange.From || port > clusterRange.To {
+				return true
+			}
+
+			if _, ok := allocated[port]; ok {
+				return true
+			}
+
+			_, ok := h.reserved[port]
+
+			return ok
+		})
+		if !ok {
+			return 0, NewNodePortsExhausted(*spec)
+		}
+
+		allocated[port] = struct{}{}
+		h.reserved[port] = now
+
+		return port, nil
+	}, nil
+}
+
+// assign sets the node ports of the Service, returning true if it has been changed: upon update, the node ports already
+// allocated to the Service are kept, as the API Server does for the ports not specifying them.
+func (h *nodePortDefaultHandler) assign(svc, old *corev1.Service, next func() (int32, error)) (changed bool, err error) {
+	if needsNodePorts(svc) {
+		for i, p := range svc.Spec.Ports {
+			if p.NodePort > 0 {
+				continue
+			}
+
+			if old != nil && needsNodePorts(old) {
+				for _, o := range old.Spec.Ports {
+					if o.Port == p.Port && o.Protocol == p.Protocol && o.NodePort > 0 {
+						svc.Spec.Ports[i].NodePort = o.NodePort
+						break
+					}
+				}
+			}
+
+			if svc.Spec.Ports[i].NodePort == 0 {
+				if svc.Spec.Ports[i].NodePort, err = next(); err != nil {
+					return false, err
+				}
+			}
+			changed = true
+		}
+	}
+
+	if needsHealthCheckNodePort(svc) && svc.Spec.HealthCheckNodePort == 0 {
+		if old != nil && needsHealthCheckNodePort(old) && old.Spec.HealthCheckNodePort > 0 {
+			svc.Spec.HealthCheckNodePort = old.Spec.HealthCheckNodePort
+		} else if svc.Spec.HealthCheckNodePort, err = next(); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+func (h *nodePortDefaultHandler) handle(ctx context.Context, c client.Client, decoder *admission.Decoder, req admission.Request, recorder record.EventRecorder, old *corev1.Service) *admission.Response {
+	svc := &corev1.Service{}
+	if err := decoder.Decode(req, svc); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if !needsNodePorts(svc) && !needsHealthCheckNodePort(svc) {
+		return nil
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := c.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", req.Namespace),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	tnt := tntList.Items[0]
+
+	if tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.NodePorts == nil {
+		return nil
+	}
+
+	next, err := h.allocator(ctx, c, tnt.Spec.ServiceOptions.NodePorts, svc)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	changed, err := h.assign(svc, old, next)
+	if err != nil {
+		if _, ok := err.(*nodePortsExhausted); ok {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ExhaustedNodePorts", "Service %s/%s cannot be assigned a node port, the ones allowed for the current Tenant are exhausted", req.Namespace, req.Name)
+
+			response := admission.Denied(err.Error())
+
+			return &response
+		}
+
+		return utils.ErroredResponse(err)
+	}
+	if !changed {
+		return nil
+	}
+
+	recorder.Eventf(&tnt, corev1.EventTypeNormal, "AssignedNodePorts", "Service %s/%s has been assigned the node ports %v", req.Namespace, req.Name, nodePorts(svc))
+
+	marshaled, err := json.Marshal(svc)
+	if err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	response := admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+
+	return &response
+}
+
+func (h *nodePortDefaultHandler) OnCreate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return h.handle(ctx, c, decoder, req, recorder, nil)
+	}
+}
+
+func (h *nodePortDefaultHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *nodePortDefaultHandler) OnUpdate(c client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		old := &corev1.Service{}
+		if err := decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		return h.handle(ctx, c, decoder, req, recorder, old)
+	}
+}
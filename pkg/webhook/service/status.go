@@ -0,0 +1,68 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type statusHandler struct{}
+
+// StatusHandler validates the load balancer ingress IPs set through the status subresource: it must be wrapped by
+// utils.InCapsuleGroups, since the cloud controllers assigning them must never be rejected.
+func StatusHandler() capsulewebhook.Handler {
+	return &statusHandler{}
+}
+
+func (r *statusHandler) handleStatus(ctx context.Context, clt client.Client, decoder *admission.Decoder, req admission.Request, recorder record.EventRecorder) *admission.Response {
+	if req.SubResource != "status" {
+		return nil
+	}
+
+	svc := &corev1.Service{}
+	if err := decoder.Decode(req, svc); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	tntList := &capsulev1beta1.TenantList{}
+	if err := clt.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", svc.GetNamespace()),
+	}); err != nil {
+		return utils.ErroredResponse(err)
+	}
+
+	if len(tntList.Items) == 0 {
+		return nil
+	}
+
+	return handleIngressIPs(req, recorder, tntList.Items[0], svc)
+}
+
+func (r *statusHandler) OnCreate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (r *statusHandler) OnUpdate(client client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return r.handleStatus(ctx, client, decoder, req, recorder)
+	}
+}
+
+func (r *statusHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		return nil
+	}
+}
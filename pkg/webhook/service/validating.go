@@ -5,9 +5,8 @@ package service
 
 import (
 	"context"
-	"net"
-	"strings"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/record"
@@ -44,6 +43,20 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 
 	tnt := tntList.Items[0]
 
+	// the status subresource is validated by the StatusHandler, only for the Capsule users
+	if req.SubResource == "status" {
+		return nil
+	}
+
+	// the previous Service is decoded once, the checks skip what was already allowed
+	var old *corev1.Service
+	if req.Operation == admissionv1.Update {
+		old = &corev1.Service{}
+		if err := decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return utils.ErroredResponse(err)
+		}
+	}
+
 	if svc.Spec.Type == corev1.ServiceTypeNodePort && tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.AllowedServices != nil && !*tnt.Spec.ServiceOptions.AllowedServices.NodePort {
 		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenNodePort", "Service %s/%s cannot be type of NodePort for the current Tenant", req.Namespace, req.Name)
 
@@ -60,38 +73,19 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 		return &response
 	}
 
-	if svc.Spec.ExternalIPs == nil || (tnt.Spec.ServiceOptions == nil || tnt.Spec.ServiceOptions.ExternalServiceIPs == nil) {
-		return nil
-	}
-
-	ipInCIDR := func(ip net.IP) bool {
-		for _, allowed := range tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed {
-			if !strings.Contains(string(allowed), "/") {
-				allowed += "/32"
-			}
-
-			_, allowedIP, _ := net.ParseCIDR(string(allowed))
-
-			if allowedIP.Contains(ip) {
-				return true
-			}
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if response := r.handleLoadBalancer(ctx, clt, decoder, req, recorder, tnt, svc, old); response != nil {
+			return response
 		}
-		return false
 	}
 
-	for _, externalIP := range svc.Spec.ExternalIPs {
-		ip := net.ParseIP(externalIP)
-
-		if !ipInCIDR(ip) {
-			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
-
-			response := admission.Denied(NewExternalServiceIPForbidden(tnt.Spec.ServiceOptions.ExternalServiceIPs.Allowed).Error())
-
-			return &response
+	if tnt.Spec.ServiceOptions != nil && tnt.Spec.ServiceOptions.NodePorts != nil {
+		if response := r.handleNodePorts(req, recorder, tnt, svc, old); response != nil {
+			return response
 		}
 	}
 
-	return nil
+	return r.handleExternalIPs(req, recorder, tnt, svc)
 }
 
 func (r *handler) OnCreate(client client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
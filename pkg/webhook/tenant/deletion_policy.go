@@ -0,0 +1,144 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type deletionPolicyHandler struct {
+}
+
+// DeletionPolicyHandler enforces the Tenant deletion policy: a Tenant with the Deny policy cannot be deleted
+// as long as it has Namespaces, while a Tenant with the Orphan policy, or with the Cascade one and adopted Namespaces,
+// cannot be deleted in foreground, neither before the controller has put the finalizer on it, since the garbage
+// collector would delete the Namespaces before they are detached.
+func DeletionPolicyHandler() capsulewebhook.Handler {
+	return &deletionPolicyHandler{}
+}
+
+func (h *deletionPolicyHandler) OnCreate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *deletionPolicyHandler) OnDelete(clt client.Client, decoder *admission.Decoder, recorder record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		tnt := &capsulev1beta1.Tenant{}
+		if err := decoder.DecodeRaw(req.OldObject, tnt); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		var err error
+
+		switch tnt.GetDeletionPolicy() {
+		case capsulev1beta1.DeletionPolicyDeny:
+			namespaces, listErr := h.namespaces(ctx, clt, tnt, false)
+			if listErr != nil {
+				return utils.ErroredResponse(listErr)
+			}
+
+			if len(namespaces) == 0 {
+				return nil
+			}
+
+			err = NewDeletionDeniedError(tnt.GetName(), namespaces)
+		case capsulev1beta1.DeletionPolicyCascade:
+			// the adopted Namespaces were created outside of Capsule, they're detached rather than deleted along with the Tenant
+			namespaces, listErr := h.namespaces(ctx, clt, tnt, true)
+			if listErr != nil {
+				return utils.ErroredResponse(listErr)
+			}
+
+			if len(namespaces) == 0 {
+				return nil
+			}
+
+			if err = h.orphanError(tnt, req.Options); err == nil {
+				return nil
+			}
+		case capsulev1beta1.DeletionPolicyOrphan:
+			if err = h.orphanError(tnt, req.Options); err == nil {
+				return nil
+			}
+		default:
+			return nil
+		}
+
+		recorder.Eventf(tnt, corev1.EventTypeWarning, "TenantDeletionDenied", "%s", err.Error())
+
+		response := admission.Denied(err.Error())
+
+		return &response
+	}
+}
+
+func (h *deletionPolicyHandler) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+// namespaces returns the sorted names of the Tenant Namespaces, only the adopted ones if requested.
+func (h *deletionPolicyHandler) namespaces(ctx context.Context, clt client.Client, tnt *capsulev1beta1.Tenant, adopted bool) ([]string, error) {
+	nl := &corev1.NamespaceList{}
+	if err := clt.List(ctx, nl, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".metadata.ownerReferences[*].capsule", tnt.GetName()),
+	}); err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0, len(nl.Items))
+	for _, ns := range nl.Items {
+		if _, ok := ns.GetAnnotations()[capsulev1beta1.AdoptedNamespaceAnnotation]; adopted && !ok {
+			continue
+		}
+		namespaces = append(namespaces, ns.GetName())
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// orphanError returns the error denying the deletion of a Tenant whose Namespaces would be deleted before being
+// detached by the controller.
+func (h *deletionPolicyHandler) orphanError(tnt *capsulev1beta1.Tenant, options runtime.RawExtension) error {
+	switch {
+	case !controllerutil.ContainsFinalizer(tnt, capsulev1beta1.OrphanNamespacesFinalizer):
+		return NewOrphanFinalizerMissingError(tnt.GetName(), tnt.GetDeletionPolicy())
+	case h.isForegroundDeletion(options):
+		return NewForegroundDeletionError(tnt.GetName(), tnt.GetDeletionPolicy())
+	default:
+		return nil
+	}
+}
+
+func (h *deletionPolicyHandler) isForegroundDeletion(raw runtime.RawExtension) bool {
+	if len(raw.Raw) == 0 {
+		return false
+	}
+
+	opts := &metav1.DeleteOptions{}
+	if err := json.Unmarshal(raw.Raw, opts); err != nil {
+		return false
+	}
+
+	return opts.PropagationPolicy != nil && *opts.PropagationPolicy == metav1.DeletePropagationForeground
+}
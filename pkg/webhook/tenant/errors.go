@@ -0,0 +1,53 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+type deletionDeniedError struct {
+	tenant     string
+	namespaces []string
+}
+
+func NewDeletionDeniedError(tenant string, namespaces []string) error {
+	return &deletionDeniedError{
+		tenant:     tenant,
+		namespaces: namespaces,
+	}
+}
+
+func (e deletionDeniedError) Error() string {
+	return fmt.Sprintf("Tenant %s cannot be deleted since its deletion policy is Deny and it still has the following Namespaces: %s", e.tenant, strings.Join(e.namespaces, ", "))
+}
+
+type foregroundDeletionError struct {
+	tenant string
+	policy capsulev1beta1.DeletionPolicy
+}
+
+func NewForegroundDeletionError(tenant string, policy capsulev1beta1.DeletionPolicy) error {
+	return &foregroundDeletionError{tenant: tenant, policy: policy}
+}
+
+func (e foregroundDeletionError) Error() string {
+	return fmt.Sprintf("Tenant %s has the %s deletion policy and cannot be deleted in foreground, since the Namespaces to be detached would be deleted: please, use the background propagation policy", e.tenant, e.policy)
+}
+
+type orphanFinalizerMissingError struct {
+	tenant string
+	policy capsulev1beta1.DeletionPolicy
+}
+
+func NewOrphanFinalizerMissingError(tenant string, policy capsulev1beta1.DeletionPolicy) error {
+	return &orphanFinalizerMissingError{tenant: tenant, policy: policy}
+}
+
+func (e orphanFinalizerMissingError) Error() string {
+	return fmt.Sprintf("Tenant %s has the %s deletion policy, but Capsule has not yet put the finalizer detaching its Namespaces: please, retry in a while", e.tenant, e.policy)
+}
@@ -5,22 +5,28 @@ package tenant
 
 import (
 	"context"
-	"regexp"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/configuration"
 	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
 	"github.com/clastix/capsule/pkg/webhook/utils"
 )
 
 type nameHandler struct {
+	configuration configuration.Configuration
 }
 
-func NameHandler() capsulewebhook.Handler {
-	return &nameHandler{}
+// NameHandler validates the name of the new Tenants: it's used as label value, and to name the objects
+// replicated in the Tenant Namespaces, thus it must be a DNS-1123 label, not longer than the configured limit.
+func NameHandler(configuration configuration.Configuration) capsulewebhook.Handler {
+	return &nameHandler{configuration: configuration}
 }
 
 func (h *nameHandler) OnCreate(_ client.Client, decoder *admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
@@ -30,14 +36,9 @@ func (h *nameHandler) OnCreate(_ client.Client, decoder *admission.Decoder, _ re
 			return utils.ErroredResponse(err)
 		}
 
-		matched, _ := regexp.MatchString(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`, tenant.GetName())
-		if !matched {
-			response := admission.Denied("tenant name has forbidden characters")
+		errs := validateName(tenant.GetName(), h.configuration.TenantNameMaxLength(), h.configuration.ReservedTenantNames(), field.NewPath("metadata", "name"))
 
-			return &response
-		}
-
-		return nil
+		return invalidResponse(tenant, errs)
 	}
 }
 
@@ -47,8 +48,31 @@ func (h *nameHandler) OnDelete(client.Client, *admission.Decoder, record.EventRe
 	}
 }
 
+// OnUpdate doesn't validate the name, since it's immutable: a change of the configuration,
+// such as a lower maximum length, must not lock the already existing Tenants.
 func (h *nameHandler) OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
 		return nil
 	}
 }
+
+func validateName(name string, maxLength int, reserved []string, path *field.Path) (errs field.ErrorList) {
+	for _, msg := range validation.IsDNS1123Label(name) {
+		errs = append(errs, field.Invalid(path, name, msg))
+	}
+
+	// a name longer than a DNS-1123 label has already been reported as invalid
+	if len(name) > maxLength && len(name) <= validation.DNS1123LabelMaxLength {
+		errs = append(errs, field.TooLong(path, name, maxLength))
+	}
+
+	for _, r := range reserved {
+		if r == name {
+			errs = append(errs, field.Forbidden(path, fmt.Sprintf("%s is a reserved Tenant name", name)))
+
+			break
+		}
+	}
+
+	return
+}
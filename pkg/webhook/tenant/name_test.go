@@ -0,0 +1,45 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateName(t *testing.T) {
+	reserved := []string{"default", "kube-system"}
+
+	tests := []struct {
+		name      string
+		maxLength int
+		errors    []field.ErrorType
+	}{
+		{name: "oil", maxLength: 63},
+		{name: "oil-2", maxLength: 63},
+		{name: strings.Repeat("a", 63), maxLength: 63},
+		{name: "Oil", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: "non_rfc_dns_1123", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: "oil.gas", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: "-oil", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: strings.Repeat("a", 64), maxLength: 63, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: strings.Repeat("a", 64), maxLength: 10, errors: []field.ErrorType{field.ErrorTypeInvalid}},
+		{name: "oil-production", maxLength: 10, errors: []field.ErrorType{field.ErrorTypeTooLong}},
+		{name: "default", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeForbidden}},
+		{name: "kube-system", maxLength: 63, errors: []field.ErrorType{field.ErrorTypeForbidden}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var types []field.ErrorType
+			for _, err := range validateName(tc.name, tc.maxLength, reserved, field.NewPath("metadata", "name")) {
+				types = append(types, err.Type)
+			}
+			assert.ElementsMatch(t, tc.errors, types)
+		})
+	}
+}
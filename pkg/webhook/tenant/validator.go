@@ -0,0 +1,154 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+	"github.com/clastix/capsule/pkg/configuration"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+	"github.com/clastix/capsule/pkg/webhook/utils"
+)
+
+type validatorHandler struct {
+	configuration configuration.Configuration
+}
+
+// Validator checks the whole Tenant specification, returning all the invalid fields at once, along with their path:
+// a malformed Tenant would be accepted by the API server, silently breaking the enforcement of its policies.
+func Validator(configuration configuration.Configuration) capsulewebhook.Handler {
+	return &validatorHandler{configuration: configuration}
+}
+
+func (h *validatorHandler) OnCreate(clt client.Client, decoder *admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		tenant := &capsulev1beta1.Tenant{}
+		if err := decoder.Decode(req, tenant); err != nil {
+			return utils.ErroredResponse(err)
+		}
+
+		errs := validateSpec(tenant.Spec, field.NewPath("spec"))
+		errs = append(errs, h.validateClusterRoles(ctx, clt, nil, tenant.Spec.AdditionalRoleBindings)...)
+		errs = append(errs, validateNodePorts(nil, tenant.Spec.ServiceOptions, h.configuration.NodePortRange())...)
+
+		return invalidResponse(tenant, errs)
+	}
+}
+
+func (h *validatorHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *validatorHandler) OnUpdate(clt client.Client, decoder *admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		oldTenant, newTenant := &capsulev1beta1.Tenant{}, &capsulev1beta1.Tenant{}
+		if err := decoder.DecodeRaw(req.OldObject, oldTenant); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		if err := decoder.Decode(req, newTenant); err != nil {
+			return utils.ErroredResponse(err)
+		}
+		// the Tenant being deleted, or updated by the controller with an untouched specification
+		// (e.g. finalizers, or status), must not be locked by a pre-existing invalid field.
+		if newTenant.GetDeletionTimestamp() != nil || equality.Semantic.DeepEqual(oldTenant.Spec, newTenant.Spec) {
+			return nil
+		}
+
+		errs := validateSpec(newTenant.Spec, field.NewPath("spec"))
+		errs = append(errs, h.validateClusterRoles(ctx, clt, oldTenant.Spec.AdditionalRoleBindings, newTenant.Spec.AdditionalRoleBindings)...)
+		errs = append(errs, validateNodePorts(oldTenant.Spec.ServiceOptions, newTenant.Spec.ServiceOptions, h.configuration.NodePortRange())...)
+
+		return invalidResponse(newTenant, errs)
+	}
+}
+
+// validateClusterRoles ensures the ClusterRoles referred by the additional RoleBindings exist:
+// on update, only the ones not referred by the previous version of the Tenant are checked.
+func (h *validatorHandler) validateClusterRoles(ctx context.Context, clt client.Client, previous, bindings []capsulev1beta1.AdditionalRoleBindingsSpec) (errs field.ErrorList) {
+	existing := make(map[string]struct{})
+	for _, binding := range previous {
+		existing[binding.ClusterRoleName] = struct{}{}
+	}
+
+	path := field.NewPath("spec", "additionalRoleBindings")
+
+	for i, binding := range bindings {
+		if _, ok := existing[binding.ClusterRoleName]; ok || len(binding.ClusterRoleName) == 0 {
+			continue
+		}
+
+		if err := clt.Get(ctx, types.NamespacedName{Name: binding.ClusterRoleName}, &rbacv1.ClusterRole{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, field.NotFound(path.Index(i).Child("clusterRoleName"), binding.ClusterRoleName))
+				continue
+			}
+			errs = append(errs, field.InternalError(path.Index(i).Child("clusterRoleName"), err))
+		}
+	}
+
+	return
+}
+
+// validateNodePorts ensures the node ports allowed for the Tenant are contained in the node port range of the cluster,
+// otherwise the Services would be rejected by the API Server: on update, only the ones not previously allowed are checked.
+func validateNodePorts(previous, options *capsulev1beta1.ServiceOptions, clusterRange capsulev1beta1.NodePortRange) (errs field.ErrorList) {
+	if options == nil || options.NodePorts == nil {
+		return
+	}
+
+	existing := &capsulev1beta1.NodePortsSpec{}
+	if previous != nil && previous.NodePorts != nil {
+		existing = previous.NodePorts
+	}
+
+	msg := fmt.Sprintf("must be contained in the node port range of the cluster, %d-%d", clusterRange.From, clusterRange.To)
+
+	path := field.NewPath("spec", "serviceOptions", "nodePorts")
+
+	for i, r := range options.NodePorts.Ranges {
+		if existing.ContainsRange(r) || (r.From >= clusterRange.From && r.To <= clusterRange.To) {
+			continue
+		}
+		errs = append(errs, field.Invalid(path.Child("ranges").Index(i), fmt.Sprintf("%d-%d", r.From, r.To), msg))
+	}
+
+	for i, p := range options.NodePorts.Ports {
+		if existing.Contains(p) || (p >= clusterRange.From && p <= clusterRange.To) {
+			continue
+		}
+		errs = append(errs, field.Invalid(path.Child("ports").Index(i), p, msg))
+	}
+
+	return
+}
+
+// invalidResponse denies the request with the Invalid status, listing all the invalid fields along with their path.
+func invalidResponse(tenant *capsulev1beta1.Tenant, errs field.ErrorList) *admission.Response {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	err := apierrors.NewInvalid(capsulev1beta1.GroupVersion.WithKind("Tenant").GroupKind(), tenant.GetName(), errs)
+
+	return &admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &err.ErrStatus,
+		},
+	}
+}
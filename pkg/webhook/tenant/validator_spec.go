@@ -0,0 +1,298 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+var supportedPullPolicies = []string{string(corev1.PullAlways), string(corev1.PullNever), string(corev1.PullIfNotPresent)}
+
+// validateSpec returns all the errors of the Tenant specification, the ones requiring the cluster state excluded.
+func validateSpec(spec capsulev1beta1.TenantSpec, path *field.Path) (errs field.ErrorList) {
+	errs = append(errs, validateOwners(spec.Owners, path.Child("owners"))...)
+
+	if spec.NamespacesMetadata != nil {
+		errs = append(errs, validateMetadata(spec.NamespacesMetadata, path.Child("namespacesMetadata"))...)
+	}
+
+	if spec.ServiceOptions != nil {
+		errs = append(errs, validateServiceOptions(spec.ServiceOptions, path.Child("serviceOptions"))...)
+	}
+
+	errs = append(errs, validateDefaultAllowedList(spec.StorageClasses, path.Child("storageClasses"))...)
+	errs = append(errs, validateDefaultAllowedList(spec.IngressClasses, path.Child("ingressClasses"))...)
+	errs = append(errs, validateDefaultAllowedList(spec.PriorityClasses, path.Child("priorityClasses"))...)
+	errs = append(errs, validateAllowedList(spec.IngressHostnames, path.Child("ingressHostnames"))...)
+	errs = append(errs, validateAllowedList(spec.ContainerRegistries, path.Child("containerRegistries"))...)
+
+	if spec.ContainerImages != nil {
+		errs = append(errs, validateContainerImages(spec.ContainerImages, path.Child("containerImages"))...)
+	}
+
+	errs = append(errs, metav1validation.ValidateLabels(spec.NodeSelector, path.Child("nodeSelector"))...)
+	errs = append(errs, validateImagePullPolicies(spec.ImagePullPolicies, path.Child("imagePullPolicies"))...)
+
+	if spec.ResourceQuota != nil {
+		errs = append(errs, validateResourceQuotas(spec.ResourceQuota.Items, path.Child("resourceQuotas", "items"))...)
+	}
+
+	errs = append(errs, validateAdditionalRoleBindings(spec.AdditionalRoleBindings, path.Child("additionalRoleBindings"))...)
+
+	if spec.NamespaceAdoption != nil {
+		errs = append(errs, validateNamespaceAdoption(spec.NamespaceAdoption, path.Child("namespaceAdoption"))...)
+	}
+
+	if spec.PersistentVolumeClaims != nil && spec.PersistentVolumeClaims.MaxSize != nil && spec.PersistentVolumeClaims.MaxSize.Sign() <= 0 {
+		errs = append(errs, field.Invalid(path.Child("persistentVolumeClaims", "maxSize"), spec.PersistentVolumeClaims.MaxSize.String(), "must be greater than zero"))
+	}
+
+	return
+}
+
+func validateOwners(owners capsulev1beta1.OwnerListSpec, path *field.Path) (errs field.ErrorList) {
+	seen := make(map[string]struct{})
+
+	for i, owner := range owners {
+		idxPath := path.Index(i)
+
+		if len(owner.Name) == 0 {
+			errs = append(errs, field.Required(idxPath.Child("name"), ""))
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+		if _, ok := seen[key]; ok {
+			errs = append(errs, field.Duplicate(idxPath, fmt.Sprintf("%s %s", owner.Kind, owner.Name)))
+		}
+		seen[key] = struct{}{}
+
+		if owner.Kind != capsulev1beta1.ServiceAccountOwner {
+			continue
+		}
+
+		namespace, name, err := owner.ServiceAccountNamespacedName()
+		if err != nil {
+			errs = append(errs, field.Invalid(idxPath.Child("name"), owner.Name, err.Error()))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			errs = append(errs, field.Invalid(idxPath.Child("name"), owner.Name, "namespace "+msg))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			errs = append(errs, field.Invalid(idxPath.Child("name"), owner.Name, "name "+msg))
+		}
+	}
+
+	return
+}
+
+func validateMetadata(metadata *capsulev1beta1.AdditionalMetadataSpec, path *field.Path) (errs field.ErrorList) {
+	errs = append(errs, metav1validation.ValidateLabels(metadata.AdditionalLabels, path.Child("additionalLabels"))...)
+	errs = append(errs, apivalidation.ValidateAnnotations(metadata.AdditionalAnnotations, path.Child("additionalAnnotations"))...)
+
+	return
+}
+
+func validateAllowedIPs(allowed []capsulev1beta1.AllowedIP, path *field.Path) (errs field.ErrorList) {
+	for i, ip := range allowed {
+		if _, err := ip.Network(); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i), string(ip), err.Error()))
+		}
+	}
+
+	return
+}
+
+func validateServiceOptions(options *capsulev1beta1.ServiceOptions, path *field.Path) (errs field.ErrorList) {
+	if options.AdditionalMetadata != nil {
+		errs = append(errs, validateMetadata(options.AdditionalMetadata, path.Child("additionalMetadata"))...)
+	}
+
+	if options.ExternalServiceIPs != nil {
+		errs = append(errs, validateAllowedIPs(options.ExternalServiceIPs.Allowed, path.Child("externalIPs", "allowed"))...)
+	}
+
+	if lb := options.LoadBalancers; lb != nil {
+		errs = append(errs, validateAllowedList(lb.AllowedClasses, path.Child("loadBalancers", "allowedClasses"))...)
+		errs = append(errs, validateAllowedIPs(lb.AllowedSourceRanges, path.Child("loadBalancers", "allowedSourceRanges"))...)
+	}
+
+	if np := options.NodePorts; np != nil {
+		for i, r := range np.Ranges {
+			if r.From > r.To {
+				errs = append(errs, field.Invalid(path.Child("nodePorts", "ranges").Index(i), fmt.Sprintf("%d-%d", r.From, r.To), "from must not be greater than to"))
+			}
+		}
+		for i, p := range np.Ports {
+			for _, msg := range validation.IsValidPortNum(int(p)) {
+				errs = append(errs, field.Invalid(path.Child("nodePorts", "ports").Index(i), p, msg))
+			}
+		}
+	}
+
+	return
+}
+
+// validateAllowedList returns an error if the regex doesn't compile, returning it as well for further checks.
+func validateAllowedList(spec *capsulev1beta1.AllowedListSpec, path *field.Path) (errs field.ErrorList) {
+	if spec == nil || len(spec.Regex) == 0 {
+		return
+	}
+
+	if _, err := regexp.Compile(spec.Regex); err != nil {
+		errs = append(errs, field.Invalid(path.Child("allowedRegex"), spec.Regex, err.Error()))
+	}
+
+	return
+}
+
+func validateDefaultAllowedList(spec *capsulev1beta1.DefaultAllowedListSpec, path *field.Path) (errs field.ErrorList) {
+	if spec == nil {
+		return
+	}
+
+	errs = validateAllowedList(&spec.AllowedListSpec, path)
+
+	// the default class is checked only when the regex compiles, since matching it would panic otherwise
+	if len(spec.Default) > 0 && len(errs) == 0 && !spec.ExactMatch(spec.Default) && !spec.RegexMatch(spec.Default) {
+		errs = append(errs, field.Invalid(path.Child("default"), spec.Default, "must be one of the allowed classes, or match the allowed regex"))
+	}
+
+	return
+}
+
+func validateContainerImages(spec *capsulev1beta1.ContainerImagesSpec, path *field.Path) (errs field.ErrorList) {
+	if policy := spec.DefaultPullPolicy.String(); len(policy) > 0 {
+		if !isSupportedPullPolicy(policy) {
+			errs = append(errs, field.NotSupported(path.Child("defaultPullPolicy"), policy, supportedPullPolicies))
+		}
+	}
+
+	for from, to := range spec.RegistryRewrites {
+		if len(from) == 0 {
+			errs = append(errs, field.Invalid(path.Child("registryRewrites"), from, "the rewritten registry cannot be empty"))
+		}
+		if len(to) == 0 {
+			errs = append(errs, field.Required(path.Child("registryRewrites").Key(from), "the target registry cannot be empty"))
+		}
+	}
+
+	return
+}
+
+func isSupportedPullPolicy(policy string) bool {
+	for _, p := range supportedPullPolicies {
+		if p == policy {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateImagePullPolicies(policies []capsulev1beta1.ImagePullPolicySpec, path *field.Path) (errs field.ErrorList) {
+	seen := make(map[capsulev1beta1.ImagePullPolicySpec]struct{})
+
+	for i, policy := range policies {
+		if !isSupportedPullPolicy(policy.String()) {
+			errs = append(errs, field.NotSupported(path.Index(i), policy.String(), supportedPullPolicies))
+		}
+		if _, ok := seen[policy]; ok {
+			errs = append(errs, field.Duplicate(path.Index(i), policy.String()))
+		}
+		seen[policy] = struct{}{}
+	}
+
+	return
+}
+
+// validateResourceQuotas rejects the items with the same scope constraining the same resource:
+// the hard quota enforced for the resource would depend on the order of the items.
+func validateResourceQuotas(items []capsulev1beta1.ResourceQuotaItem, path *field.Path) (errs field.ErrorList) {
+	for i, item := range items {
+		for name, hard := range item.Hard {
+			if hard.Sign() < 0 {
+				errs = append(errs, field.Invalid(path.Index(i).Child("hard").Key(name.String()), hard.String(), "must be greater than or equal to zero"))
+			}
+
+			for j := 0; j < i; j++ {
+				other := items[j]
+				if _, ok := other.Hard[name]; !ok || other.IsTenantScoped() != item.IsTenantScoped() {
+					continue
+				}
+				if !equality.Semantic.DeepEqual(other.Scopes, item.Scopes) || !equality.Semantic.DeepEqual(other.ScopeSelector, item.ScopeSelector) {
+					continue
+				}
+
+				errs = append(errs, field.Duplicate(path.Index(i).Child("hard").Key(name.String()), fmt.Sprintf("%s, already constrained by item %d", name, j)))
+			}
+		}
+	}
+
+	return
+}
+
+func validateAdditionalRoleBindings(bindings []capsulev1beta1.AdditionalRoleBindingsSpec, path *field.Path) (errs field.ErrorList) {
+	for i, binding := range bindings {
+		idxPath := path.Index(i)
+
+		if len(binding.ClusterRoleName) == 0 {
+			errs = append(errs, field.Required(idxPath.Child("clusterRoleName"), ""))
+		}
+
+		if len(binding.Subjects) == 0 {
+			errs = append(errs, field.Required(idxPath.Child("subjects"), "at least one subject is required"))
+		}
+
+		for j, subject := range binding.Subjects {
+			subjectPath := idxPath.Child("subjects").Index(j)
+
+			if len(subject.Name) == 0 {
+				errs = append(errs, field.Required(subjectPath.Child("name"), ""))
+			}
+
+			switch subject.Kind {
+			case rbacv1.UserKind, rbacv1.GroupKind:
+			case rbacv1.ServiceAccountKind:
+				if len(subject.Namespace) == 0 {
+					errs = append(errs, field.Required(subjectPath.Child("namespace"), "ServiceAccount subjects require a namespace"))
+				}
+			default:
+				errs = append(errs, field.NotSupported(subjectPath.Child("kind"), subject.Kind, []string{rbacv1.UserKind, rbacv1.GroupKind, rbacv1.ServiceAccountKind}))
+			}
+		}
+	}
+
+	return
+}
+
+func validateNamespaceAdoption(spec *capsulev1beta1.NamespaceAdoptionSpec, path *field.Path) (errs field.ErrorList) {
+	for i, ns := range spec.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			errs = append(errs, field.Invalid(path.Child("namespaces").Index(i), ns, msg))
+		}
+	}
+
+	if spec.Selector != nil {
+		// an empty selector matches all the Namespaces, including the ones of the cluster components
+		if len(spec.Selector.MatchLabels) == 0 && len(spec.Selector.MatchExpressions) == 0 {
+			errs = append(errs, field.Required(path.Child("selector"), "an empty selector would adopt all the Namespaces"))
+		}
+		errs = append(errs, metav1validation.ValidateLabelSelector(spec.Selector, path.Child("selector"))...)
+	}
+
+	return
+}
@@ -0,0 +1,179 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestValidateSpec(t *testing.T) {
+	owners := capsulev1beta1.OwnerListSpec{{Kind: capsulev1beta1.UserOwner, Name: "alice"}}
+
+	tests := []struct {
+		name  string
+		spec  capsulev1beta1.TenantSpec
+		paths []string
+	}{
+		{
+			name: "valid",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: capsulev1beta1.OwnerListSpec{
+					{Kind: capsulev1beta1.UserOwner, Name: "alice"},
+					{Kind: capsulev1beta1.ServiceAccountOwner, Name: "system:serviceaccount:oil-development:robot"},
+				},
+				NamespacesMetadata: &capsulev1beta1.AdditionalMetadataSpec{
+					AdditionalLabels: map[string]string{"capsule.clastix.io/team": "oil"},
+				},
+				StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+					AllowedListSpec: capsulev1beta1.AllowedListSpec{Regex: "^ssd-.*$"},
+					Default:         "ssd-fast",
+				},
+				ServiceOptions: &capsulev1beta1.ServiceOptions{
+					ExternalServiceIPs: &capsulev1beta1.ExternalServiceIPsSpec{Allowed: []capsulev1beta1.AllowedIP{"10.0.0.0/24", "2001:db8::1"}},
+					NodePorts:          &capsulev1beta1.NodePortsSpec{Ranges: []capsulev1beta1.NodePortRange{{From: 30000, To: 30100}}},
+				},
+				ImagePullPolicies: []capsulev1beta1.ImagePullPolicySpec{"Always", "IfNotPresent"},
+			},
+		},
+		{
+			name: "malformed ServiceAccount owner",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: capsulev1beta1.OwnerListSpec{
+					{Kind: capsulev1beta1.ServiceAccountOwner, Name: "robot"},
+					{Kind: capsulev1beta1.ServiceAccountOwner, Name: "system:serviceaccount:Oil:robot"},
+				},
+			},
+			paths: []string{"spec.owners[0].name", "spec.owners[1].name"},
+		},
+		{
+			name: "duplicate owner",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: capsulev1beta1.OwnerListSpec{
+					{Kind: capsulev1beta1.UserOwner, Name: "alice"},
+					{Kind: capsulev1beta1.UserOwner, Name: "alice"},
+				},
+			},
+			paths: []string{"spec.owners[1]"},
+		},
+		{
+			name: "invalid metadata",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				NamespacesMetadata: &capsulev1beta1.AdditionalMetadataSpec{
+					AdditionalLabels:      map[string]string{"invalid key": "value"},
+					AdditionalAnnotations: map[string]string{"-invalid": "value"},
+				},
+			},
+			paths: []string{"spec.namespacesMetadata.additionalLabels", "spec.namespacesMetadata.additionalAnnotations"},
+		},
+		{
+			name: "invalid regex and default class",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				IngressClasses: &capsulev1beta1.DefaultAllowedListSpec{
+					AllowedListSpec: capsulev1beta1.AllowedListSpec{Regex: "(invalid"},
+					Default:         "nginx",
+				},
+				StorageClasses: &capsulev1beta1.DefaultAllowedListSpec{
+					AllowedListSpec: capsulev1beta1.AllowedListSpec{Exact: []string{"ssd"}},
+					Default:         "hdd",
+				},
+				ContainerRegistries: &capsulev1beta1.AllowedListSpec{Regex: "[a-z"},
+			},
+			paths: []string{"spec.ingressClasses.allowedRegex", "spec.storageClasses.default", "spec.containerRegistries.allowedRegex"},
+		},
+		{
+			name: "invalid service options",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				ServiceOptions: &capsulev1beta1.ServiceOptions{
+					ExternalServiceIPs: &capsulev1beta1.ExternalServiceIPsSpec{Allowed: []capsulev1beta1.AllowedIP{"10.0.0.0/24", "10.0.0.256"}},
+					LoadBalancers:      &capsulev1beta1.LoadBalancerSpec{AllowedSourceRanges: []capsulev1beta1.AllowedIP{"not-an-ip"}},
+					NodePorts: &capsulev1beta1.NodePortsSpec{
+						Ranges: []capsulev1beta1.NodePortRange{{From: 30100, To: 30000}},
+						Ports:  []int32{0},
+					},
+				},
+			},
+			paths: []string{
+				"spec.serviceOptions.externalIPs.allowed[1]",
+				"spec.serviceOptions.loadBalancers.allowedSourceRanges[0]",
+				"spec.serviceOptions.nodePorts.ranges[0]",
+				"spec.serviceOptions.nodePorts.ports[0]",
+			},
+		},
+		{
+			name: "invalid pull policies",
+			spec: capsulev1beta1.TenantSpec{
+				Owners:            owners,
+				ImagePullPolicies: []capsulev1beta1.ImagePullPolicySpec{"Always", "Sometimes", "Always"},
+				ContainerImages:   &capsulev1beta1.ContainerImagesSpec{DefaultPullPolicy: "Sometimes"},
+			},
+			paths: []string{"spec.imagePullPolicies[1]", "spec.imagePullPolicies[2]", "spec.containerImages.defaultPullPolicy"},
+		},
+		{
+			name: "overlapping resource quotas",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				ResourceQuota: &capsulev1beta1.ResourceQuotaSpec{Items: []capsulev1beta1.ResourceQuotaItem{
+					{ResourceQuotaSpec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}}},
+					{ResourceQuotaSpec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("5")}}},
+					{
+						Scope:             capsulev1beta1.ResourceQuotaScopeNamespace,
+						ResourceQuotaSpec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")}},
+					},
+				}},
+			},
+			paths: []string{"spec.resourceQuotas.items[1].hard[pods]"},
+		},
+		{
+			name: "invalid additional RoleBindings",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				AdditionalRoleBindings: []capsulev1beta1.AdditionalRoleBindingsSpec{
+					{ClusterRoleName: "view", Subjects: []rbacv1.Subject{{Kind: "Robot", Name: "r2d2"}, {Kind: rbacv1.ServiceAccountKind, Name: "default"}}},
+				},
+			},
+			paths: []string{"spec.additionalRoleBindings[0].subjects[0].kind", "spec.additionalRoleBindings[0].subjects[1].namespace"},
+		},
+		{
+			name: "empty adoption selector",
+			spec: capsulev1beta1.TenantSpec{
+				Owners:            owners,
+				NamespaceAdoption: &capsulev1beta1.NamespaceAdoptionSpec{Selector: &metav1.LabelSelector{}},
+			},
+			paths: []string{"spec.namespaceAdoption.selector"},
+		},
+		{
+			name: "invalid PersistentVolumeClaims max size",
+			spec: capsulev1beta1.TenantSpec{
+				Owners: owners,
+				PersistentVolumeClaims: func() *capsulev1beta1.PersistentVolumeClaimsSpec {
+					size := resource.MustParse("0")
+					return &capsulev1beta1.PersistentVolumeClaimsSpec{MaxSize: &size}
+				}(),
+			},
+			paths: []string{"spec.persistentVolumeClaims.maxSize"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []string
+			for _, err := range validateSpec(tc.spec, field.NewPath("spec")) {
+				paths = append(paths, err.Field)
+			}
+			assert.ElementsMatch(t, tc.paths, paths)
+		})
+	}
+}
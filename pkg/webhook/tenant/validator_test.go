@@ -0,0 +1,56 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+func TestValidateNodePorts(t *testing.T) {
+	clusterRange := capsulev1beta1.NodePortRange{From: 30000, To: 32767}
+
+	nodePorts := func(ranges []capsulev1beta1.NodePortRange, ports ...int32) *capsulev1beta1.ServiceOptions {
+		return &capsulev1beta1.ServiceOptions{NodePorts: &capsulev1beta1.NodePortsSpec{Ranges: ranges, Ports: ports}}
+	}
+
+	tests := []struct {
+		name     string
+		previous *capsulev1beta1.ServiceOptions
+		options  *capsulev1beta1.ServiceOptions
+		paths    []string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:    "contained",
+			options: nodePorts([]capsulev1beta1.NodePortRange{{From: 30000, To: 30100}}, 32767),
+		},
+		{
+			name:    "outside",
+			options: nodePorts([]capsulev1beta1.NodePortRange{{From: 30000, To: 30100}, {From: 32700, To: 32800}}, 29999, 31000),
+			paths:   []string{"spec.serviceOptions.nodePorts.ranges[1]", "spec.serviceOptions.nodePorts.ports[0]"},
+		},
+		{
+			name:     "previously allowed",
+			previous: nodePorts([]capsulev1beta1.NodePortRange{{From: 32700, To: 32800}}, 29999),
+			options:  nodePorts([]capsulev1beta1.NodePortRange{{From: 32700, To: 32800}, {From: 20000, To: 20100}}, 29999, 80),
+			paths:    []string{"spec.serviceOptions.nodePorts.ranges[1]", "spec.serviceOptions.nodePorts.ports[1]"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []string
+			for _, err := range validateNodePorts(tc.previous, tc.options, clusterRange) {
+				paths = append(paths, err.Field)
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	capsulev1beta1 "github.com/clastix/capsule/api/v1beta1"
+)
+
+// IsClassOmitted returns true if the requested class must be replaced by the Tenant default one: either it's not
+// specified, or it's the cluster default one, not allowed for the Tenant, as set by the API Server to the objects
+// omitting it. The provided function reports whether the named class is the cluster default one.
+func IsClassOmitted(allowed *capsulev1beta1.DefaultAllowedListSpec, class string, isClusterDefault func(name string) (bool, error)) (bool, error) {
+	if len(class) == 0 {
+		return true, nil
+	}
+
+	if allowed.ExactMatch(class) || allowed.RegexMatch(class) {
+		return false, nil
+	}
+
+	return isClusterDefault(class)
+}